@@ -0,0 +1,93 @@
+package alligotor
+
+import (
+	"strings"
+)
+
+const aliasSeparator = "|"
+
+// splitAliases splits a name from the struct tag into the name and its aliases, e.g. NEW_NAME|OLD_NAME.
+func splitAliases(val string) (string, []string) {
+	names := strings.Split(val, aliasSeparator)
+	if len(names) == 1 {
+		return val, nil
+	}
+
+	return names[0], names[1:]
+}
+
+// OnDeprecatedName registers fn to be called whenever a value is read with an alias from the struct tag,
+// e.g. OLD_NAME for `config:"env=NEW_NAME|OLD_NAME"`, so a deprecation warning can be logged.
+// field is the path of the field in the config struct, alias the name that was used
+// and name the first name that should be used instead.
+func (c *Collector) OnDeprecatedName(fn func(field string, source SourceKind, alias, name string)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.onDeprecatedName = fn
+}
+
+// explicitEnvName returns the environment variable name that is defined in the struct tag, prefixed if configured.
+// If aliases are defined the first name that is set in vars is used.
+func (c *Collector) explicitEnvName(f *field, vars map[string]string) string {
+	name := c.prefixExplicitEnvName(f.Config.DefaultEnvName)
+
+	for _, alias := range f.Config.EnvAliases {
+		if c.isEnvSet(name, vars) {
+			break
+		}
+
+		alias = c.prefixExplicitEnvName(alias)
+		if c.isEnvSet(alias, vars) {
+			c.reportDeprecatedName(f, SourceEnv, alias, name)
+
+			return alias
+		}
+	}
+
+	return name
+}
+
+// isEnvSet returns true if the environment variable or its file variable (see EnvConfig.FileSuffix) is set.
+func (c *Collector) isEnvSet(name string, vars map[string]string) bool {
+	name = strings.ToUpper(name)
+	if _, ok := vars[name]; ok {
+		return true
+	}
+
+	if c.Env.FileSuffix == "" {
+		return false
+	}
+
+	_, ok := vars[name+strings.ToUpper(c.Env.FileSuffix)]
+
+	return ok
+}
+
+// explicitFileField returns the file key that is defined in the struct tag.
+// If aliases are defined the first key that is set in m is used.
+func (c *Collector) explicitFileField(f *field, m *ciMap) string {
+	name := f.Config.DefaultFileField
+
+	for _, alias := range f.Config.FileAliases {
+		if _, ok := m.Get(name); ok {
+			break
+		}
+
+		if _, ok := m.Get(alias); ok {
+			c.reportDeprecatedName(f, SourceFile, alias, name)
+
+			return alias
+		}
+	}
+
+	return name
+}
+
+func (c *Collector) reportDeprecatedName(f *field, source SourceKind, alias, name string) {
+	c.log(LogLevelWarn, "deprecated name used", "field", f.FullName("."), "source", source, "alias", alias, "name", name)
+
+	if c.onDeprecatedName != nil {
+		c.onDeprecatedName(f.FullName("."), source, alias, name)
+	}
+}
@@ -0,0 +1,99 @@
+package alligotor
+
+import (
+	"os"
+	"path"
+	"reflect"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("aliases", func() {
+	type deprecation struct {
+		field  string
+		source SourceKind
+		alias  string
+		name   string
+	}
+
+	var c *Collector
+	var deprecations []deprecation
+
+	BeforeEach(func() {
+		c = &Collector{
+			Files: FilesConfig{Separator: "."},
+			Env:   EnvConfig{Separator: "_"},
+			Flags: FlagsConfig{Disabled: true},
+		}
+		deprecations = nil
+		c.OnDeprecatedName(func(field string, source SourceKind, alias, name string) {
+			deprecations = append(deprecations, deprecation{field, source, alias, name})
+		})
+	})
+
+	It("splits aliases in the struct tag", func() {
+		p, err := readParameterConfig("env=NEW_NAME|OLD_NAME,file=new|old")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(p).To(Equal(parameterConfig{
+			DefaultEnvName:   "NEW_NAME",
+			EnvAliases:       []string{"OLD_NAME"},
+			DefaultFileField: "new",
+			FileAliases:      []string{"old"},
+		}))
+	})
+	Describe("env", func() {
+		var cfg struct {
+			V int `config:"env=NEW_NAME|OLD_NAME|OLDEST_NAME"`
+		}
+		var fields []*field
+
+		BeforeEach(func() {
+			cfg.V = 0
+
+			var err error
+			fields, err = getFieldsConfigsFromValue(reflect.ValueOf(&cfg).Elem())
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("uses the first name that is set", func() {
+			Expect(c.readEnv(fields, map[string]string{"OLD_NAME": "2", "OLDEST_NAME": "3"})).To(Succeed())
+			Expect(cfg.V).To(Equal(2))
+			Expect(deprecations).To(Equal([]deprecation{{"V", SourceEnv, "OLD_NAME", "NEW_NAME"}}))
+		})
+		It("prefers the name over aliases", func() {
+			Expect(c.readEnv(fields, map[string]string{"NEW_NAME": "1", "OLD_NAME": "2"})).To(Succeed())
+			Expect(cfg.V).To(Equal(1))
+			Expect(deprecations).To(BeEmpty())
+		})
+		It("considers file variables", func() {
+			c.Env.FileSuffix = "_FILE"
+			dir, err := os.MkdirTemp("", "tests*")
+			Expect(err).ShouldNot(HaveOccurred())
+			defer os.RemoveAll(dir)
+			Expect(os.WriteFile(path.Join(dir, "v"), []byte("3"), 0600)).To(Succeed())
+
+			Expect(c.readEnv(fields, map[string]string{"OLDEST_NAME_FILE": path.Join(dir, "v")})).To(Succeed())
+			Expect(cfg.V).To(Equal(3))
+			Expect(deprecations).To(HaveLen(1))
+		})
+	})
+	Describe("files", func() {
+		It("uses the first key that is set", func() {
+			cfg := struct {
+				V int `config:"file=server.port|port"`
+			}{}
+			c.Files.Strict = true
+
+			Expect(c.GetFromMap(&cfg, map[string]interface{}{"port": 2})).To(Succeed())
+			Expect(cfg.V).To(Equal(2))
+			Expect(deprecations).To(Equal([]deprecation{{"V", SourceFile, "port", "server.port"}}))
+
+			deprecations = nil
+			Expect(c.GetFromMap(&cfg, map[string]interface{}{"port": 2, "server": map[string]interface{}{"port": 1}})).
+				To(Succeed())
+			Expect(cfg.V).To(Equal(1))
+			Expect(deprecations).To(BeEmpty())
+		})
+	})
+})
@@ -1,21 +1,27 @@
 package alligotor
 
 import (
+	"bytes"
 	"encoding"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
+	"net/http"
 	"os"
 	"path"
+	"path/filepath"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/pflag"
-	"gopkg.in/yaml.v3"
 )
 
 var (
@@ -25,6 +31,13 @@ var (
 	ErrNoFileFound          = errors.New("no config file could be found")
 	ErrUnsupportedType      = errors.New("invalid type")
 	ErrCantSet              = errors.New("can't set value")
+	ErrUnknownEncoding      = errors.New("unknown base64 encoding")
+	ErrMalformedMapEntry    = errors.New("malformed map entry, expected key and value")
+	ErrMalformedList        = errors.New("malformed list")
+	ErrDuplicateName        = errors.New("duplicate name")
+	ErrUnknownTimeFormat    = errors.New("unknown time format")
+	ErrEmptyFile            = errors.New("config file is empty")
+	ErrNilPointer           = errors.New("expected a non-nil pointer as input")
 )
 
 const (
@@ -33,40 +46,95 @@ const (
 	flagKey = "flag"
 	fileKey = "file"
 
+	base64Key            = "base64"
+	timeFormatKey        = "timeformat"
+	transformKey         = "transform"
+	byteSizeKey          = "bytesize"
+	separatorKey         = "sep"
+	keyValueSeparatorKey = "kvsep"
+	minKey               = "min"
+	maxKey               = "max"
+	oneOfKey             = "oneof"
+	ignoreCaseKey        = "ignorecase"
+	jsonKey              = "json"
+	countKey             = "count"
+	groupKey             = "group"
+	prefixKey            = "prefix"
+	presenceKey          = "presence"
+
 	flagConfigSeparator = " "
+	flagShortPrefix     = "short:"
+	flagLongPrefix      = "long:"
+	negatedFlagPrefix   = "no-"
+	transformSeparator  = " "
+
+	absoluteEnvNameMarker = "!"
 
 	defaultEnvSeparator  = "_"
 	defaultFileSeparator = "."
 	defaultFlagSeparator = "-"
+
+	defaultEnvFileSuffix = "_FILE"
+
+	defaultListSeparator     = ","
+	defaultKeyValueSeparator = "="
+
+	timeFormatUnix      = "unix"
+	timeFormatUnixMilli = "unixmilli"
+	dateLayout          = "2006-01-02"
+
+	globMetaChars = "*?["
 )
 
 // DefaultCollector is the default Collector and is used by Get.
-var DefaultCollector = &Collector{ // nolint: gochecknoglobals // usage just like in http package
-	Files: FilesConfig{
-		Locations: []string{"."},
-		BaseName:  "config",
-		Separator: defaultFileSeparator,
-		Disabled:  false,
-	},
-	Env: EnvConfig{
-		Prefix:    "",
-		Separator: defaultEnvSeparator,
-		Disabled:  false,
-	},
-	Flags: FlagsConfig{
-		Separator: defaultFlagSeparator,
-		Disabled:  false,
-	},
+var DefaultCollector = NewCollector() // nolint: gochecknoglobals // usage just like in http package
+
+// NewCollector returns a new Collector with the default configuration that is modified by the options.
+// Without any options the configuration is the same as the one of the DefaultCollector:
+// All configuration sources are enabled.
+// For environment variables it uses no prefix and "_" as the separator.
+// Values can be read from files with the "_FILE" suffix.
+// For flags it uses "-" as the separator.
+// For config files it uses "config" as the basename and searches in the current directory.
+// It uses "." as the separator.
+func NewCollector(opts ...Option) *Collector {
+	c := &Collector{
+		Files: FilesConfig{
+			Locations: []string{"."},
+			BaseName:  "config",
+			Separator: defaultFileSeparator,
+			Disabled:  false,
+		},
+		Env: EnvConfig{
+			Prefix:     "",
+			Separator:  defaultEnvSeparator,
+			FileSuffix: defaultEnvFileSuffix,
+			TrimSpace:  true,
+			Disabled:   false,
+		},
+		Flags: FlagsConfig{
+			Separator: defaultFlagSeparator,
+			Disabled:  false,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
 // Get is a wrapper around DefaultCollector.Get.
 // All configuration sources are enabled.
 // For environment variables it uses no prefix and "_" as the separator.
+// Values can be read from files with the "_FILE" suffix.
 // For flags it use "-" as the separator.
 // For config files it uses "config" as the basename and searches in the current directory.
 // It uses "." as the separator.
-func Get(v interface{}) error {
-	return DefaultCollector.Get(v)
+// The options can be used to override the configuration for this call only.
+func Get(v interface{}, opts ...Option) error {
+	return DefaultCollector.Get(v, opts...)
 }
 
 // Collector is the root struct that implements the main package api.
@@ -83,65 +151,373 @@ func Get(v interface{}) error {
 // configuration is supposed to be unmarshalled into. Properties that are not set in any of
 // the configuration sources will keep the preset value.
 //
+// Pointers to structs (e.g. TLS *TLSConfig) can be used for optional sections. If they are nil they are only
+// allocated if at least one of their fields is set in any of the sources, otherwise they stay nil.
+//
 // Since environment variables and flags are purely text based it also supports types that implement
 // the encoding.TextUnmarshaler interface like for example zapcore.Level and logrus.Level.
+// Types that only implement encoding.BinaryUnmarshaler are supported as well, if a type implements both
+// interfaces encoding.TextUnmarshaler is preferred.
+// This also covers big.Int and big.Float from math/big (as values or pointers), big.Int accepts the same
+// prefixes as other integers (e.g. 0xFF).
 // On top of that custom implementations are already baked into the package to support
 // duration strings using time.ParseDuration() as well as string slices ([]string) in the format val1,val2,val3
 // and string maps (map[string]string) in the format key1=val1,key2=val2.
+// Slices of all other supported types (e.g. []int or []logrus.Level) are supported in the same format,
+// each element is converted separately. The same applies to the keys and values of other maps (e.g. map[int]string),
+// also for maps in config files.
+//
+// Elements can be enclosed in double quotes to contain the separator, e.g. "a,b",c results in [a,b c].
+// The separators can be changed per field with the sep and kvsep keys in the struct tag,
+// e.g. `config:"sep=;,kvsep=:"` to parse maps in the format key1:val1;key2:val2.
+// An empty value (e.g. TAGS= or --tags="") sets an empty slice or map instead of nil,
+// all other types are set to their zero value. Sources that don't define a value at all leave the field unchanged.
+//
+// Integers can be defined using Go's integer literal syntax, so besides plain decimal values prefixed
+// hexadecimal (0xFF), octal (0o755) and binary (0b101) values are supported.
+// Be aware that this means a leading zero (e.g. 0755) is interpreted as an octal value as well.
+// Integer fields with the bytesize key in the struct tag (e.g. `config:"env=MAX_UPLOAD,bytesize"`) also accept
+// human readable byte sizes like 10MB or 1.5GiB. The units KB, MB, GB, TB and PB are interpreted as binary
+// units just like KiB, MiB, GiB, TiB and PiB, so 10MB results in 10485760.
+//
+// Byte slices ([]byte) are decoded from base64 strings using the standard encoding. Another encoding
+// can be set with the base64 key in the struct tag, e.g. `config:"base64=url"`.
+// Valid values are std, raw (standard without padding), url and rawurl (url without padding).
+//
+// Booleans accept yes/no and on/off (case insensitive) in addition to the values supported by strconv.ParseBool.
+//
+// Timestamps (time.Time) are parsed in the RFC3339 format or as dates without time (2006-01-02).
+// With the timeformat key in the struct tag they can be parsed from Unix epoch seconds (`config:"timeformat=unix"`),
+// milliseconds (`config:"timeformat=unixmilli"`) or with a layout for time.Parse (`config:"timeformat=02.01.2006"`)
+// instead. Timestamps in yaml files are parsed the same way, no matter if they're quoted or not.
+//
+// String values can be normalized with the transform key in the struct tag, e.g. `config:"transform=lower"`.
+// The built-in transforms are lower, upper and trim, others can be registered with Collector.RegisterTransform.
+// Multiple transforms are separated by spaces and applied in order, e.g. `config:"transform=trim lower"`.
+// They are applied to string fields and the elements of string slices after all sources are read.
+//
+// Numeric fields can be restricted to a range with the min and max keys in the struct tag,
+// e.g. `config:"min=1,max=64"`. Get returns ErrOutOfRange if the resulting value is outside of the range.
+//
+// String values can be restricted to a set of space separated values with the oneof key in the struct tag,
+// e.g. `config:"oneof=debug info warn error"`. Get returns ErrNotOneOf if the resulting value is not in the set.
+// The comparison is case sensitive unless the ignorecase key is set, e.g. `config:"oneof=debug info,ignorecase"`.
+//
+// Fields can be grouped with the group key in the struct tag, e.g. `config:"group=source"`. After loading,
+// exactly one field of each group needs to be set (not the zero value), other rules can be defined with
+// Collector.Groups. Get returns ErrGroupViolated otherwise.
+//
+// The names of nested structs can be changed with the prefix key in the struct tag, e.g. `config:"prefix=database"`
+// for the field Db results in the env variable DATABASE_HOST, the flag --database-host and the file key database.host
+// for its child field Host. The prefix is also used in the field paths, e.g. in errors and in SourceHit.
+//
+// Bool fields with the presence key in the struct tag (e.g. `config:"env=DEBUG,presence"`) are set to true if
+// the environment variable is set at all, e.g. DEBUG= or DEBUG=1, unless its value is a recognized false like 0 or no.
+//
+// Structs and maps can be set from a JSON string in a single environment variable or flag with the json key
+// in the struct tag, e.g. `config:"env=FEATURES,json"` for FEATURES={"a":true,"b":false}. The JSON is merged into
+// the current value and the fields of a struct can still be overridden by their own sources.
+//
+// Other types can be supported by registering a converter with Collector.RegisterConverter.
+//
+// The env and file keys in the struct tag accept aliases separated by "|", e.g. `config:"env=NEW_NAME|OLD_NAME"`,
+// to rename variables and keys without breaking existing configurations. The first name that is set is used,
+// Collector.OnDeprecatedName can be used to log a warning if an alias is used.
+//
+// Instead of a struct Get also accepts a pointer to a map[string]interface{}, e.g. for schemaless configurations.
+// It's filled with the values of all config files, which are merged recursively in the order in which the files
+// are applied. Since environment variables and flags can't be mapped to keys without knowing them upfront,
+// they're not read for maps and neither are the custom Sources.
+//
+// A Collector is safe for concurrent use, calls to Get are serialized.
+// The configuration fields must not be modified while Get is running.
 type Collector struct {
 	Files FilesConfig
 	Env   EnvConfig
 	Flags FlagsConfig
+	// Namespace is used as the Prefix for environment variables and flags if no other Prefix is set,
+	// e.g. the name of the application. If Files.UseNamespace is true it's also used as top level key in files.
+	Namespace string
+	// Sources are custom configuration sources (see Source) that are read after the kind of source
+	// defined by SourcesAfter, by default after the config files and before environment variables.
+	Sources      []Source
+	SourcesAfter SourceKind
+	// Groups defines the rules for the groups of fields defined with the group key in the struct tag.
+	// Groups that are not contained use GroupExactlyOne.
+	Groups map[string]GroupRule
+	// Logger receives events about the files that are loaded and the values that are set if it's not nil.
+	Logger Logger
+
+	mu sync.Mutex
+	// onSet is called for every value that is set from a source during get
+	onSet      func(SourceHit)
+	decoders   map[string]func([]byte) (map[string]interface{}, error)
+	converters map[reflect.Type]func(string) (interface{}, error)
+	transforms map[string]func(string) string
+	// onDeprecatedName is called if a value is read with an alias from the struct tag
+	onDeprecatedName func(field string, source SourceKind, alias, name string)
+	// loadedFiles contains the paths of the files that were loaded during the last get
+	loadedFiles []string
+	// touched contains the full names of the fields that were set from a source during the current get
+	touched map[string]bool
+	// stdin contains the content of stdin once it was read
+	stdin []byte
+	// consumedEnv contains the environment variables that were used during the current get
+	consumedEnv map[string]bool
+	// unmatchedEnv contains the prefixed environment variables that were not used during the last get
+	unmatchedEnv []string
+	// inputs are used instead of the process environment during GetFrom
+	inputs *Inputs
 }
 
 // FilesConfig is used to configure the configuration from files.
 // Locations can be used to define where to look for files with the defined BaseName.
-// Currently only json and yaml files are supported.
-// The Separator is used for nested structs.
+// Locations can also be glob patterns like /etc/example/conf.d/* in which case all matching files are loaded
+// in lexical order, regardless of their name. Matching directories are searched for files with the BaseName.
+// BaseNames can be used to search for multiple base names, e.g. while migrating from an old to a new name.
+// If both are set the BaseName is used before the BaseNames. Files in the same location are applied in the order
+// of the base names, so with the default order the files with later base names take precedence.
+// Currently json, yaml and ini files are supported, other formats can be added with Collector.RegisterDecoder.
+// For ini files the section headers are mapped to nested structs using the Separator.
+// The format is detected from the content, except for files with the .ini extension which are always parsed as ini,
+// so errors in them (e.g. the line of a malformed section header) are reported.
+// Yaml files can contain multiple documents separated by "---", they are merged in order, so later documents
+// override the values of earlier ones. Nested objects are merged recursively, all other values (e.g. lists) are replaced.
+// The Separator is used for nested structs. It's also used to resolve the file key in the struct tag,
+// so `config:"file=server.http.port"` reads the nested port key of the server.http object for any field.
+// Keys in the files are matched case insensitively and "_" and "-" are ignored, so the field DBHost
+// as well as `config:"file=db_host"` match the keys dbhost, db_host and DB-Host.
+// Fields without a file key in the struct tag use the name from their json or yaml struct tag instead of the
+// Go field name if there is one, e.g. `json:"max_conns"`, so existing annotated structs can be used as they are.
+// URLs can be used to load config files from http(s) URLs, e.g. from a config service.
+// They are loaded after the files from the Locations, so with the default order they take precedence.
+// The HTTPClient is used for the requests, if it's nil http.DefaultClient is used.
+// Each request is canceled after the URLTimeout, which defaults to 10 seconds.
+// Order defines the precedence if files are found in multiple locations (see FileOrder).
+// If Strict is true keys in the files that don't map to any field (e.g. because of a typo) result in an error
+// listing the unknown keys. Nested keys in the value of a map field are always accepted.
+// Keys are matched case insensitive and ignoring "_" and "-", so keys of a file that only differ in these
+// (e.g. db_host and dbhost) result in ErrDuplicateKey if they match the same field.
+// Locations that don't exist or aren't directories are skipped, but errors reading existing locations or files
+// (e.g. missing permissions) are returned. If IgnoreReadErrors is true these locations and files are skipped as well.
+// If ErrorOnEmpty is true files that are empty or only contain whitespace result in ErrEmptyFile
+// instead of being applied without any values, e.g. to detect secrets that are mounted incorrectly.
+// Not finding any config file is fine since the values can still be set from env vars and flags,
+// if Required is true Get returns ErrNoFileFound instead.
+// Keys that are defined in the struct tags can be shared by multiple fields to set them from a single key.
+// If UniqueNames is true Get returns ErrDuplicateName for shared keys instead, e.g. to detect copy-paste errors.
+// If UseNamespace is true only the values in the object at the Collector's Namespace key are used,
+// e.g. {"myapp": {"port": 8080}} for the Namespace "myapp", so multiple applications can share a file.
+// Naming defines the NamingStrategy for the keys of the fields, it's mostly relevant for Collector.Save
+// since keys are matched case insensitively and ignoring "_" and "-" anyway.
+// If it's nil the Go field names are used as they are.
+// PathEnvVar is the name of an environment variable that can be used to define the path of the config file,
+// e.g. MYAPP_CONFIG=/path/to/config.yaml. If it's set only that file is loaded and the Locations and URLs
+// are not used. The variable's name is used as it is, without the Env.Prefix.
+// PathFlag is the name of a flag that can be used to define the path of the config file the same way,
+// e.g. "config" for --config /path/to/config.yaml. It takes precedence over the PathEnvVar.
+// Since the files are read before the flags the flag is looked up in the Flags.Args beforehand.
+// It's not used if the flags are disabled.
+// Profile selects a profile in the files, the values in the object at the key profiles.<Profile> are merged
+// recursively over the other values of the file, e.g. for the Profile "production":
+//
+//	port: 8080
+//	profiles:
+//	  production:
+//	    port: 80
+//
+// ProfileEnvVar is the name of an environment variable that selects the profile instead, e.g. APP_ENV.
+// If it's set it takes precedence over the Profile. Its name is used as it is, without the Env.Prefix.
+// If one of them is configured the profiles key is never matched with a field, even if no profile is selected.
+// Files with the same base name in the same location are applied in the order of their names by default,
+// e.g. config.json before config.yaml. ExtensionPriority defines the precedence of the extensions instead,
+// the first extension takes precedence, e.g. []string{"yaml", "json"} lets config.yaml override config.json
+// regardless of the Order. Files with other extensions have the lowest precedence.
+// If Stdin is true a config file is read from stdin as well, e.g. for cat config.yaml | app. It's applied after the
+// URLs, so with the default order it takes precedence. Its format is detected from the content and it's only read
+// if stdin is not a terminal to not wait for user input. Stdin is read once and its content is used for every
+// call to Get. A file path "-" in the PathEnvVar or PathFlag reads the config file from stdin as well.
 // If Disabled is true the configuration from files is skipped.
 type FilesConfig struct {
-	Locations []string
-	BaseName  string
-	Separator string
-	Disabled  bool
+	Locations         []string
+	BaseName          string
+	BaseNames         []string
+	Separator         string
+	URLs              []string
+	HTTPClient        *http.Client
+	URLTimeout        time.Duration
+	PathEnvVar        string
+	PathFlag          string
+	Profile           string
+	ProfileEnvVar     string
+	Order             FileOrder
+	ExtensionPriority []string
+	Stdin             bool
+	IgnoreReadErrors  bool
+	Strict            bool
+	ErrorOnEmpty      bool
+	Required          bool
+	UniqueNames       bool
+	UseNamespace      bool
+	Naming            NamingStrategy
+	Disabled          bool
 }
 
+// FileOrder defines which file takes precedence if config files are found in multiple locations.
+// Files are always collected in the order of the Locations slice and
+// multiple matching files in the same location are sorted by their name (see FilesConfig.ExtensionPriority).
+type FileOrder int
+
+const (
+	// LastWins applies the files in the order they were found,
+	// so files in later locations overwrite the values from files in earlier locations.
+	// This is the default and can be used for something like system defaults in /etc that are overwritten
+	// by user specific config files in $HOME.
+	LastWins FileOrder = iota
+	// FirstWins applies the files in reverse order, so files in earlier locations take precedence.
+	FirstWins
+)
+
 // EnvConfig is used to configure the configuration from environment variables.
 // Prefix can be defined the Collector should look for environment variables with a certain prefix.
 // Separator is used for nested structs and also for the Prefix.
 // As an example:
 // If Prefix is set to "example", the Separator is set to "_" and the config struct's field is named Port,
 // the Collector will by default look for the environment variable "EXAMPLE_PORT"
+// Names that are defined in the struct tags (e.g. `config:"env=LEGACY_PORT"`) are used verbatim by default,
+// if PrefixExplicitNames is true they are prefixed as well, so the example would result in EXAMPLE_LEGACY_PORT.
+// Names with a leading "!" (e.g. `config:"env=!HOME"`) are never prefixed, e.g. for standard variables.
+// If the generated names of multiple fields are the same (e.g. for the fields Db_Host and Db.Host), fields with
+// an explicit name only use their explicit name, so these fields can be distinguished by setting explicit names.
+// Explicit names can be shared by multiple fields to set them from a single variable. If UniqueNames is true
+// Get returns ErrDuplicateName for shared explicit names instead, e.g. to detect copy-paste errors.
+// If SnakeCase is true the field names are split into words on case boundaries, so the field MaxConnections
+// results in MAX_CONNECTIONS instead of MAXCONNECTIONS. The words are always joined with "_".
+// Naming can be used to define another NamingStrategy, it takes precedence over SnakeCase.
+// The resulting names are always uppercased.
+// Elements of slices can be set with indexed environment variables, e.g. EXAMPLE_SERVERS_0_HOST sets the Host
+// field of the first element of the Servers slice and EXAMPLE_TAGS_1 the second element of Tags.
+// The slices are grown as needed.
+// If FileSuffix is set (NewCollector uses "_FILE") the value can also be read from a file, e.g. for Docker secrets.
+// If EXAMPLE_PASSWORD_FILE=/run/secrets/password is set, the trimmed content of the file is used for EXAMPLE_PASSWORD.
+// If both variables are set, the value of EXAMPLE_PASSWORD takes precedence.
+// Fields of struct types don't use file variables and file variables that are the name of another field are only
+// used for that field, e.g. EXAMPLE_CERT_FILE for CertFile instead of Cert. With UniqueNames these result in
+// ErrDuplicateName instead.
+// If TrimSpace is true (NewCollector enables it) leading and trailing whitespace is removed from the values,
+// e.g. trailing newlines of values that are read from files by the orchestration.
 // If Disabled is true the configuration from environment variables is skipped.
 type EnvConfig struct {
-	Prefix    string
-	Separator string
-	Disabled  bool
+	Prefix              string
+	Separator           string
+	SnakeCase           bool
+	Naming              NamingStrategy
+	PrefixExplicitNames bool
+	FileSuffix          string
+	UniqueNames         bool
+	TrimSpace           bool
+	Disabled            bool
 }
 
 // FlagsConfig is used to configure the configuration from command line flags.
+// Prefix can be defined to namespace the generated long flag names, e.g. with the Prefix set to "example"
+// and the Separator set to "-" the field Port can be set with --example-port.
+// Names and shorthands that are defined in the struct tags are not prefixed.
 // Separator is used for nested structs to construct flag names from parent and child properties recursively.
+// It's independent of the separators of the other sources, e.g. "." results in --server.port while the
+// environment variable is still SERVER_PORT.
+// Args can be used to define the arguments that are parsed for flags, if it is nil os.Args[1:] is used.
+// Arguments after the flag terminator "--" are positional arguments and never parsed as flags.
+// Flags for bool fields can be set without a value (e.g. --enabled), to set them to false use --enabled=false
+// or the negated flag --no-enabled. If both are set the last one wins. Negated flags are not supported with UseStdFlag.
+// Negated flags are skipped if the name is used by another field, e.g. --no-cache sets NoCache instead of negating Cache.
+// Flags for slice fields can be repeated to add more elements (e.g. --tag a --tag b,c results in [a b c]).
+// Flags for int fields with the count key in the struct tag count how often they are set,
+// e.g. -vvv results in 3 for `config:"flag=v,count"`. Count flags are not supported with UseStdFlag.
+// Naming defines the NamingStrategy for the generated long flag names, e.g. with KebabCase the field
+// MaxConnections results in --max-connections instead of --maxconnections. The resulting names are always lowercased.
+// If UseStdFlag is true the flags are parsed with the flag package of the standard library instead of pflag.
+// In that case flags can be set with a single or double dash, shorthands are not supported
+// and unknown flags result in an error.
+// If OnlyTagged is true the flags with the generated long names are not registered, so only fields with a flag
+// in the struct tag can be set by flags, using the names from the tag. Fields with only a shorthand in the tag
+// still use the generated long name since pflag needs a long name for every flag. If multiple fields share
+// a name from the tag only the shorthand of the first one is registered.
 // If Disabled is true the configuration from flags is skipped.
 type FlagsConfig struct {
-	Separator string
-	Disabled  bool
+	Prefix     string
+	Separator  string
+	Args       []string
+	Naming     NamingStrategy
+	UseStdFlag bool
+	OnlyTagged bool
+	Disabled   bool
 }
 
 type field struct {
-	Base   []string
-	Name   string
+	Base []string
+	Name string
+	// FileNames contains the names from the json or yaml struct tags of the field and all parent fields,
+	// indexed like Base followed by Name. Names without such a tag are empty.
+	FileNames []string
+	// Type is the type of the struct field, it's also known if Value is invalid because of a nil pointer.
+	Type   reflect.Type
 	Value  reflect.Value
 	Config parameterConfig
 }
 
+// valueType returns the type of the field's value, which is the type of the struct field with pointers dereferenced.
+// In contrast to Value.Type it's also defined for nil pointers.
+func (f *field) valueType() reflect.Type {
+	if f.Type.Kind() == reflect.Ptr {
+		return f.Type.Elem()
+	}
+
+	return f.Type
+}
+
 func (f *field) FullName(separator string) string {
 	return strings.Join(append(f.Base, f.Name), separator)
 }
 
 type parameterConfig struct {
-	DefaultFileField string
-	DefaultEnvName   string
-	Flag             flag
+	DefaultFileField  string
+	FileAliases       []string
+	DefaultEnvName    string
+	EnvAliases        []string
+	Flag              flag
+	Base64Encoding    *base64.Encoding
+	ByteSize          bool
+	ListSeparator     string
+	KeyValueSeparator string
+	TimeFormat        string
+	Transforms        []string
+	Min               string
+	Max               string
+	OneOf             []string
+	IgnoreCase        bool
+	JSON              bool
+	Count             bool
+	Group             string
+	Prefix            string
+	Presence          bool
+	Converters        map[reflect.Type]func(string) (interface{}, error)
+}
+
+func (p parameterConfig) listSeparator() string {
+	if p.ListSeparator == "" {
+		return defaultListSeparator
+	}
+
+	return p.ListSeparator
+}
+
+func (p parameterConfig) keyValueSeparator() string {
+	if p.KeyValueSeparator == "" {
+		return defaultKeyValueSeparator
+	}
+
+	return p.KeyValueSeparator
 }
 
 type flag struct {
@@ -154,50 +530,216 @@ type flag struct {
 // If the input param is not a pointer, Get will return an error.
 //
 // Get looks for config variables all sources that are not disabled.
+// If multiple fields result in the same generated environment variable or flag name,
+// Get returns an ErrDuplicateName error naming the conflicting fields.
 // Further usage details can be found in the examples or the Collector struct's documentation.
-func (c *Collector) Get(v interface{}) error {
-	value := reflect.ValueOf(v)
+//
+// Get can be called concurrently, each call holds a lock on the Collector until all sources are read,
+// so concurrent calls for the same v don't interleave their writes.
+//
+// The options are applied on top of the Collector's configuration for this call only,
+// e.g. c.Get(&cfg, WithoutFlags()) ignores the flags without modifying c.
+func (c *Collector) Get(v interface{}, opts ...Option) error {
+	return c.getWithOptions(v, opts, true)
+}
+
+// checkPointer returns ErrPointerExpected if value is not a pointer and ErrNilPointer if it's a nil pointer.
+func checkPointer(value reflect.Value) error {
 	if value.Kind() != reflect.Ptr {
 		return ErrPointerExpected
 	}
 
+	if value.IsNil() {
+		return ErrNilPointer
+	}
+
+	return nil
+}
+
+// checkStructPointer returns the errors of checkPointer and ErrUnsupportedType if value doesn't point to a struct,
+// e.g. for map targets that are only supported by Get.
+func checkStructPointer(value reflect.Value) error {
+	if err := checkPointer(value); err != nil {
+		return err
+	}
+
+	if value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: expected a pointer to a struct, not %s", ErrUnsupportedType, value.Type())
+	}
+
+	return nil
+}
+
+// getWithOptions calls get with the options applied to a copy of the configuration for this call only.
+// The state of the call is kept afterwards, e.g. for LoadedFiles and UnmatchedEnv.
+func (c *Collector) getWithOptions(v interface{}, opts []Option, validateFields bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(opts) == 0 {
+		return c.get(v, validateFields)
+	}
+
+	call := c.withOptions(opts)
+	err := call.get(v, validateFields)
+
+	c.loadedFiles, c.touched, c.consumedEnv, c.unmatchedEnv = call.loadedFiles, call.touched, call.consumedEnv, call.unmatchedEnv
+	c.stdin = call.stdin
+
+	return err
+}
+
+// LoadedFiles returns the paths and URLs of the config files that were loaded by the last call to Get,
+// in the order in which they were applied. It's empty if no file was found or reading files is disabled.
+// Reloads by Collector.Watch and calls to Collector.Explain update the loaded files as well.
+func (c *Collector) LoadedFiles() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]string(nil), c.loadedFiles...)
+}
+
+// GetFromMap sets the config struct v from the values in m, e.g. from a key value store,
+// just like the values from a config file. The keys are matched using the Files configuration,
+// so nested structs are read from nested maps or keys joined by the Files.Separator and with Files.Strict
+// unknown keys result in an error. The other sources are not read.
+func (c *Collector) GetFromMap(v interface{}, m map[string]interface{}) error {
+	value := reflect.ValueOf(v)
+	if err := checkStructPointer(value); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.touched = map[string]bool{}
+
+	sections := allocateOptionalSections(reflect.Indirect(value))
+	defer c.resetUntouchedSections(sections)
+
+	fields, err := getFieldsConfigsFromValue(reflect.Indirect(value))
+	if err != nil {
+		return err
+	}
+
+	fileMap := newCiMap(withSeparator(c.Files.Separator))
+	for key, val := range m {
+		fileMap.m[key] = stringKeyMaps(val)
+	}
+
+	fileMap = c.applyProfile(c.namespacedMap(fileMap))
+
+	if err := c.readFileMap(fields, fileMap); err != nil {
+		return err
+	}
+
+	if c.Files.Strict {
+		if err := c.checkUnknownKeys(fields, fileMap, "map"); err != nil {
+			return err
+		}
+	}
+
+	if err := c.applyTransforms(fields); err != nil {
+		return err
+	}
+
+	return validate(fields, c.Groups)
+}
+
+// get reads all sources that are not disabled into v. The constraints defined in the struct tags are only checked
+// if validateFields is true, so they can be checked once after multiple Collectors are applied.
+func (c *Collector) get(v interface{}, validateFields bool) error {
+	value := reflect.ValueOf(v)
+	if err := checkPointer(value); err != nil {
+		return err
+	}
+
 	t := reflect.Indirect(value)
 
+	c.loadedFiles = nil
+	c.touched = map[string]bool{}
+	c.consumedEnv = map[string]bool{}
+	c.unmatchedEnv = nil
+
+	if t.Kind() == reflect.Map {
+		return c.getMap(t)
+	}
+
+	// nil struct pointers are allocated to be able to read their fields and reset afterwards if they're not set
+	sections := allocateOptionalSections(t)
+	defer c.resetUntouchedSections(sections)
+
 	// collect info about fields with tags, value...
 	fields, err := getFieldsConfigsFromValue(t)
 	if err != nil {
 		return err
 	}
 
+	if err := c.checkDuplicateNames(fields); err != nil {
+		return err
+	}
+
 	// read files
 	if !c.Files.Disabled {
-		if err := readFiles(fields, c.Files); err != nil {
-			if !errors.Is(err, ErrNoFileFound) {
-				fmt.Printf("could not find any files, proceeding with env and flags")
-
-				return err
-			}
+		// not finding any file is fine since env and flags can still be used unless files are required,
+		// but files that can't be read are not
+		if err := c.readFiles(fields); err != nil && (c.Files.Required || !errors.Is(err, ErrNoFileFound)) {
+			return err
 		}
 	}
 
+	if err := c.readSourcesAfter(SourceFile, fields); err != nil {
+		return err
+	}
+
 	// read env
 	if !c.Env.Disabled {
-		if err := readEnv(fields, c.Env, getEnvAsMap()); err != nil {
+		vars := c.envVars()
+		if err := c.readEnv(fields, vars); err != nil {
 			return err
 		}
+
+		c.unmatchedEnv = c.findUnmatchedEnv(vars)
+	}
+
+	if err := c.readSourcesAfter(SourceEnv, fields); err != nil {
+		return err
 	}
 
 	// read flags
 	if !c.Flags.Disabled {
-		if err := readPFlags(fields, c.Flags, os.Args[1:]); err != nil {
+		args := c.flagArgs()
+
+		readFlags := c.readPFlags
+		if c.Flags.UseStdFlag {
+			readFlags = c.readStdFlags
+		}
+
+		if err := readFlags(fields, args); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	if err := c.readSourcesAfter(SourceFlag, fields); err != nil {
+		return err
+	}
+
+	if err := c.applyTransforms(fields); err != nil {
+		return err
+	}
+
+	if !validateFields {
+		return nil
+	}
+
+	return validate(fields, c.Groups)
 }
 
 func getFieldsConfigsFromValue(value reflect.Value, base ...string) ([]*field, error) {
+	return getFieldsConfigs(value, base, nil)
+}
+
+func getFieldsConfigs(value reflect.Value, base, fileNames []string) ([]*field, error) {
 	var fields []*field
 
 	for i := 0; i < value.NumField(); i++ {
@@ -209,17 +751,29 @@ func getFieldsConfigsFromValue(value reflect.Value, base ...string) ([]*field, e
 			return nil, err
 		}
 
+		name := configName(fieldType, fieldConfig)
+
+		fileName := tagFileName(fieldType.Tag)
+		if name != fieldType.Name {
+			// the prefix takes precedence over the json and yaml struct tags
+			fileName = ""
+		}
+
+		fieldFileNames := append(append([]string{}, fileNames...), fileName)
+
 		fields = append(fields, &field{
-			Base:   base,
-			Name:   fieldType.Name,
-			Value:  fieldValue,
-			Config: fieldConfig,
+			Base:      base,
+			Name:      name,
+			FileNames: fieldFileNames,
+			Type:      fieldType.Type,
+			Value:     fieldValue,
+			Config:    fieldConfig,
 		})
 
-		if fieldValue.Kind() == reflect.Struct {
-			newBase := append(base, fieldType.Name)
+		if fieldValue.Kind() == reflect.Struct && !isUnmarshaler(fieldValue.Type()) {
+			newBase := append(append([]string{}, base...), name)
 
-			subFields, err := getFieldsConfigsFromValue(fieldValue, newBase...)
+			subFields, err := getFieldsConfigs(fieldValue, newBase, fieldFileNames)
 			if err != nil {
 				return nil, err
 			}
@@ -231,6 +785,21 @@ func getFieldsConfigsFromValue(value reflect.Value, base ...string) ([]*field, e
 	return fields, nil
 }
 
+// configName returns the name of the struct field that is used in the field paths and to generate the names
+// in the sources. Structs and pointers to structs use the prefix from the struct tag if there is one.
+func configName(structField reflect.StructField, config parameterConfig) string {
+	t := structField.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if config.Prefix != "" && t.Kind() == reflect.Struct {
+		return config.Prefix
+	}
+
+	return structField.Name
+}
+
 func readParameterConfig(configStr string) (parameterConfig, error) {
 	fieldConfig := parameterConfig{}
 
@@ -240,8 +809,23 @@ func readParameterConfig(configStr string) (parameterConfig, error) {
 
 	for _, paramStr := range strings.Split(configStr, ",") {
 		keyVal := strings.SplitN(paramStr, "=", 2)
-		if len(keyVal) != 2 {
-			panic("invalid config struct tag format")
+		if len(keyVal) == 1 {
+			switch keyVal[0] {
+			case byteSizeKey:
+				fieldConfig.ByteSize = true
+			case ignoreCaseKey:
+				fieldConfig.IgnoreCase = true
+			case jsonKey:
+				fieldConfig.JSON = true
+			case countKey:
+				fieldConfig.Count = true
+			case presenceKey:
+				fieldConfig.Presence = true
+			default:
+				panic("invalid config struct tag format")
+			}
+
+			continue
 		}
 
 		for _, v := range keyVal {
@@ -255,9 +839,9 @@ func readParameterConfig(configStr string) (parameterConfig, error) {
 
 		switch key {
 		case envKey:
-			fieldConfig.DefaultEnvName = val
+			fieldConfig.DefaultEnvName, fieldConfig.EnvAliases = splitAliases(val)
 		case fileKey:
-			fieldConfig.DefaultFileField = val
+			fieldConfig.DefaultFileField, fieldConfig.FileAliases = splitAliases(val)
 		case flagKey:
 			flagConf, err := readFlagConfig(val)
 			if err != nil {
@@ -265,9 +849,48 @@ func readParameterConfig(configStr string) (parameterConfig, error) {
 			}
 
 			fieldConfig.Flag = flagConf
+		case base64Key:
+			encoding, err := readBase64Encoding(val)
+			if err != nil {
+				return parameterConfig{}, err
+			}
+
+			fieldConfig.Base64Encoding = encoding
+		case timeFormatKey:
+			if val != timeFormatUnix && val != timeFormatUnixMilli && !isTimeLayout(val) {
+				return parameterConfig{}, ErrUnknownTimeFormat
+			}
+
+			fieldConfig.TimeFormat = val
+		case transformKey:
+			fieldConfig.Transforms = strings.Split(val, transformSeparator)
+		case separatorKey:
+			fieldConfig.ListSeparator = val
+		case keyValueSeparatorKey:
+			fieldConfig.KeyValueSeparator = val
+		case minKey, maxKey:
+			if _, err := strconv.ParseFloat(val, 64); err != nil {
+				return parameterConfig{}, fmt.Errorf("%w %q", ErrInvalidBound, val)
+			}
+
+			if key == minKey {
+				fieldConfig.Min = val
+			} else {
+				fieldConfig.Max = val
+			}
+		case oneOfKey:
+			fieldConfig.OneOf = strings.Fields(val)
+		case groupKey:
+			fieldConfig.Group = val
+		case prefixKey:
+			fieldConfig.Prefix = val
 		default:
 			panic(
-				fmt.Sprintf("only %s, %s, and %s are allowed as config tag keys", envKey, fileKey, flagKey),
+				fmt.Sprintf(
+					"only %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s and %s are allowed as config tag keys",
+					envKey, fileKey, flagKey, base64Key, timeFormatKey, transformKey, separatorKey, keyValueSeparatorKey,
+					minKey, maxKey, oneOfKey, groupKey, prefixKey,
+				),
 			)
 		}
 	}
@@ -275,182 +898,1087 @@ func readParameterConfig(configStr string) (parameterConfig, error) {
 	return fieldConfig, nil
 }
 
-func readFiles(fields []*field, config FilesConfig) error {
-	fileFound := false
-
-	for _, fileLocation := range config.Locations {
-		fileInfos, err := ioutil.ReadDir(fileLocation)
-		if err != nil {
-			continue
+func (c *Collector) readFiles(fields []*field) error {
+	return c.forEachFile(func(filePath string, m *ciMap) error {
+		if err := c.readFileMap(fields, m); err != nil {
+			return err
 		}
 
-		for _, fileInfo := range fileInfos {
-			name := fileInfo.Name()
-			if strings.TrimSuffix(name, path.Ext(name)) != config.BaseName {
-				continue
-			}
-
-			fileFound = true
-
-			fileBytes, err := ioutil.ReadFile(path.Join(fileLocation, name))
-			if err != nil {
-				return err
-			}
+		if c.Files.Strict {
+			return c.checkUnknownKeys(fields, m, filePath)
+		}
 
-			m, err := unmarshal(config.Separator, fileBytes)
-			if err != nil {
-				return err
-			}
+		return nil
+	})
+}
 
-			if err := readFileMap(fields, config.Separator, m); err != nil {
-				return err
-			}
-		}
+// forEachFile reads and decodes all config files in the order in which they're applied and calls read for each of them
+// with the values at the Namespace key if Files.UseNamespace is set.
+func (c *Collector) forEachFile(read func(filePath string, m *ciMap) error) error {
+	filePaths, err := c.configFilePaths()
+	if err != nil {
+		return err
 	}
 
-	if !fileFound {
+	if len(filePaths) == 0 {
+		c.log(LogLevelDebug, "no config file found")
+
 		return ErrNoFileFound
 	}
 
-	return nil
-}
+	c.log(LogLevelDebug, "config files found", "files", filePaths)
 
-func readFileMap(fields []*field, separator string, m *ciMap) error {
-	for _, f := range fields {
-		fieldNames := []string{
-			f.Config.DefaultFileField,
-			f.FullName(separator),
+	if c.Files.Order == FirstWins {
+		for i, j := 0, len(filePaths)-1; i < j; i, j = i+1, j-1 {
+			filePaths[i], filePaths[j] = filePaths[j], filePaths[i]
 		}
+	}
+
+	for _, filePath := range filePaths {
+		fileBytes, err := c.readFile(filePath)
+		if err != nil {
+			if c.Files.IgnoreReadErrors && !isURL(filePath) {
+				c.log(LogLevelWarn, "skipping config file that can't be read", "file", filePath, "error", err)
 
-		for _, fieldName := range fieldNames {
-			valueForField, ok := m.Get(fieldName)
-			if !ok {
 				continue
 			}
 
-			fieldTypeZero := reflect.Zero(f.Value.Type())
-			v := fieldTypeZero.Interface()
+			return err
+		}
 
-			if err := mapstructure.Decode(valueForField, &v); err != nil {
-				// if theres a type mismatch check if value is a string and try to use setFromString (e.g. for duration strings)
-				if valueString, ok := valueForField.(string); ok {
-					if err := setFromString(f.Value, valueString); err != nil {
-						return err
-					}
+		if c.Files.ErrorOnEmpty && len(bytes.TrimSpace(fileBytes)) == 0 {
+			return fmt.Errorf("%w: %s", ErrEmptyFile, filePath)
+		}
 
-					continue
-				}
+		m, err := c.decode(filePath, fileBytes)
+		if err != nil {
+			c.log(LogLevelDebug, "config file can't be decoded", "file", filePath, "error", err)
 
-				// if the target is a struct there are also fields for the child properties and it should be tried
-				// to set these before returning an error
-				if f.Value.Kind() == reflect.Struct {
-					continue
-				}
+			return err
+		}
 
-				return err
-			}
+		c.log(LogLevelInfo, "config file loaded", "file", filePath)
+		c.loadedFiles = append(c.loadedFiles, filePath)
 
-			f.Value.Set(reflect.ValueOf(v))
+		if err := read(filePath, c.applyProfile(c.namespacedMap(m))); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
-func getEnvAsMap() map[string]string {
-	envMap := map[string]string{}
+// configFilePaths returns the path from the PathFlag or the PathEnvVar if one of them is set,
+// otherwise the paths of the files found in the Locations followed by the URLs and stdin,
+// or the paths of the input files during GetFrom.
+func (c *Collector) configFilePaths() ([]string, error) {
+	if filePath, ok := c.explicitFilePath(); ok {
+		return []string{filePath}, nil
+	}
 
-	envKeyVal := os.Environ()
-	for _, keyVal := range envKeyVal {
-		split := strings.SplitN(keyVal, "=", 2)
-		envMap[split[0]] = split[1]
+	if c.inputs != nil {
+		return c.inputFilePaths(), nil
 	}
 
-	return envMap
+	filePaths, err := findFiles(c.Files)
+	if err != nil {
+		return nil, err
+	}
+
+	filePaths = append(filePaths, c.Files.URLs...)
+
+	if c.Files.Stdin && stdinAvailable() {
+		filePaths = append(filePaths, stdinPath)
+	}
+
+	return filePaths, nil
 }
 
-func readEnv(fields []*field, config EnvConfig, vars map[string]string) error {
-	for _, f := range fields {
-		distinctEnvName := f.FullName(config.Separator)
-		if config.Prefix != "" {
-			distinctEnvName = config.Prefix + config.Separator + distinctEnvName
-		}
+// explicitFilePath returns the path of the config file from the PathFlag or the PathEnvVar if one of them is set.
+func (c *Collector) explicitFilePath() (string, bool) {
+	if filePath, ok := c.pathFromFlag(); ok {
+		c.log(LogLevelDebug, "config file set by flag", "flag", c.Files.PathFlag, "file", filePath)
 
-		envNames := []string{
-			f.Config.DefaultEnvName,
-			distinctEnvName,
-		}
+		return filePath, true
+	}
 
-		for _, envName := range envNames {
-			envVal, ok := vars[strings.ToUpper(envName)]
-			if !ok {
-				continue
-			}
+	if c.Files.PathEnvVar != "" {
+		if filePath := c.getenv(c.Files.PathEnvVar); filePath != "" {
+			c.log(LogLevelDebug, "config file set by env", "env", c.Files.PathEnvVar, "file", filePath)
 
-			if err := setFromString(f.Value, envVal); err != nil {
-				return err
-			}
+			return filePath, true
 		}
 	}
 
-	return nil
-}
-
-type flagInfo struct {
-	valueStr *string
-	flag     *pflag.Flag
+	return "", false
 }
 
-func readPFlags(fields []*field, config FlagsConfig, args []string) error {
-	flagSet := pflag.NewFlagSet("config", pflag.ContinueOnError)
-	flagSet.ParseErrorsWhitelist = pflag.ParseErrorsWhitelist{UnknownFlags: true}
+// findFiles returns the paths of all files matching the base names in the order of the configured locations.
+// Multiple matching files in the same location are sorted by name.
+// Locations containing glob patterns are expanded, matching files are used regardless of the base names,
+// matching directories are searched for files with the base names.
+func findFiles(config FilesConfig) ([]string, error) {
+	var filePaths []string
 
-	fieldToFlagInfo := make(map[*field][]*flagInfo)
-	fieldCache := map[string]*flagInfo{}
+	for _, fileLocation := range config.Locations {
+		if !strings.ContainsAny(fileLocation, globMetaChars) {
+			dirFilePaths, err := findFilesInDir(fileLocation, config)
+			if err != nil && !config.IgnoreReadErrors {
+				return nil, err
+			}
 
-	for _, f := range fields {
-		longName := strings.ToLower(f.FullName(config.Separator))
-		defaultName := f.Config.Flag.DefaultName
+			filePaths = append(filePaths, dirFilePaths...)
 
-		defaultFlag, ok := fieldCache[defaultName]
-		if !ok {
-			defaultFlag = &flagInfo{
-				valueStr: flagSet.StringP(defaultName, "", "", "default"),
-				flag:     flagSet.Lookup(defaultName),
-			}
-			fieldCache[defaultName] = defaultFlag
+			continue
 		}
 
-		fieldToFlagInfo[f] = []*flagInfo{
-			defaultFlag,
-			{
-				valueStr: flagSet.StringP(longName, f.Config.Flag.ShortName, "", "specific"),
-				flag:     flagSet.Lookup(longName),
-			},
+		// Glob returns the matches in lexical order
+		matches, err := filepath.Glob(fileLocation)
+		if err != nil {
+			return nil, err
 		}
-	}
 
-	if err := flagSet.Parse(args); err != nil {
-		return err
-	}
+		for _, match := range matches {
+			fileInfo, err := os.Stat(match)
+			if err != nil {
+				if os.IsNotExist(err) || config.IgnoreReadErrors {
+					continue
+				}
+
+				return nil, err
+			}
+
+			if fileInfo.IsDir() {
+				dirFilePaths, err := findFilesInDir(match, config)
+				if err != nil && !config.IgnoreReadErrors {
+					return nil, err
+				}
+
+				filePaths = append(filePaths, dirFilePaths...)
+
+				continue
+			}
+
+			filePaths = append(filePaths, match)
+		}
+	}
+
+	return filePaths, nil
+}
+
+// findFilesInDir returns the paths of all files in dir matching one of the base names.
+// The files are sorted by the order of the base names first and by the ExtensionPriority and name second.
+// A dir that doesn't exist or isn't a directory is skipped, any other error (e.g. missing permissions) is returned.
+func findFilesInDir(dir string, config FilesConfig) ([]string, error) {
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		if fileInfo, statErr := os.Stat(dir); statErr == nil && !fileInfo.IsDir() {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var filePaths []string
+
+	for _, baseName := range config.baseNames() {
+		var baseNamePaths []string
+
+		// ReadDir returns the entries sorted by name
+		for _, dirEntry := range dirEntries {
+			name := dirEntry.Name()
+			if strings.TrimSuffix(name, path.Ext(name)) != baseName {
+				continue
+			}
+
+			baseNamePaths = append(baseNamePaths, path.Join(dir, name))
+		}
+
+		// the files with the highest priority need to be applied last, which is the first file in the list with FirstWins
+		sort.SliceStable(baseNamePaths, func(i, j int) bool {
+			if config.Order == FirstWins {
+				return config.extensionRank(baseNamePaths[i]) > config.extensionRank(baseNamePaths[j])
+			}
+
+			return config.extensionRank(baseNamePaths[i]) < config.extensionRank(baseNamePaths[j])
+		})
+
+		filePaths = append(filePaths, baseNamePaths...)
+	}
+
+	return filePaths, nil
+}
+
+// extensionRank returns the precedence of the file's extension defined by the ExtensionPriority,
+// files with a higher rank take precedence. Extensions that are not part of the ExtensionPriority get the rank 0.
+func (config FilesConfig) extensionRank(filePath string) int {
+	ext := strings.TrimPrefix(path.Ext(filePath), ".")
+
+	for i, priorityExt := range config.ExtensionPriority {
+		if strings.EqualFold(strings.TrimPrefix(priorityExt, "."), ext) {
+			return len(config.ExtensionPriority) - i
+		}
+	}
+
+	return 0
+}
+
+// baseNames returns the BaseName followed by the BaseNames.
+func (config FilesConfig) baseNames() []string {
+	if config.BaseName == "" {
+		return config.BaseNames
+	}
+
+	return append([]string{config.BaseName}, config.BaseNames...)
+}
+
+// matchesBaseName returns true if the file name without extension matches one of the base names.
+func (config FilesConfig) matchesBaseName(fileName string) bool {
+	for _, baseName := range config.baseNames() {
+		if strings.TrimSuffix(fileName, path.Ext(fileName)) == baseName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readFileMap sets the fields from the values in m.
+// The fields are applied in the order returned by getFieldsConfigsFromValue, in which parents come before their
+// children. So a whole struct value in the file (e.g. server: {port: 1}) is applied first and keys for the child
+// fields (e.g. server.port: 2) override it afterwards. Struct values are merged into the current value,
+// so fields that are not part of the file's struct value keep their defaults.
+func (c *Collector) readFileMap(fields []*field, m *ciMap) error {
+	for _, f := range fields {
+		fieldNames := []string{
+			c.explicitFileField(f, m),
+			c.fileFieldName(f),
+		}
+
+		for _, fieldName := range fieldNames {
+			valueForField, ok, err := m.Lookup(fieldName)
+			if err != nil {
+				return err
+			}
+
+			if !ok {
+				continue
+			}
+
+			// nil pointers to non-struct types can't be set
+			if !f.Value.IsValid() {
+				return ErrCantSet
+			}
+
+			v := reflect.Zero(f.Value.Type()).Interface()
+			if f.Value.Kind() == reflect.Struct {
+				v = deepCopy(f.Value).Interface()
+			}
+
+			if err := c.decodeFileValue(f, valueForField, &v); err != nil {
+				// if theres a type mismatch check if value is a string or number and try to use setFromString
+				// (e.g. for duration strings or unix timestamps)
+				if valueString, ok := scalarString(valueForField); ok {
+					if err := c.setFromString(f, valueString); err != nil {
+						return err
+					}
+
+					c.record(f, SourceFile, fieldName, valueString)
+
+					continue
+				}
+
+				// if the target is a struct there are also fields for the child properties and it should be tried
+				// to set these before returning an error
+				if f.Value.Kind() == reflect.Struct && !isUnmarshaler(f.Value.Type()) {
+					continue
+				}
+
+				return err
+			}
+
+			f.Value.Set(reflect.ValueOf(v))
+			c.record(f, SourceFile, fieldName, valueForField)
+		}
+	}
+
+	return nil
+}
+
+// decodeFileValue decodes a value from a config file for the field f into output with mapstructure.
+// Durations can be given as duration strings (e.g. "5s") or as numbers of nanoseconds,
+// also within slices, maps and structs. Strings for timestamps, types implementing encoding.TextUnmarshaler,
+// slices and maps are converted with setFromString just like values from environment variables and flags.
+func (c *Collector) decodeFileValue(f *field, input, output interface{}) error {
+	config := f.Config
+	config.Converters = c.converters
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			mapKeyDecodeHook(config),
+			jsonDecodeHook,
+			mapstructure.StringToTimeDurationHookFunc(),
+			stringDecodeHook(config),
+		),
+		Result: output,
+	})
+	if err != nil {
+		return err
+	}
+
+	return decoder.Decode(input)
+}
+
+// mapKeyDecodeHook returns a mapstructure decode hook that converts the keys of maps with setFromString
+// if the target map doesn't have string keys, since the keys in config files are always read as strings.
+func mapKeyDecodeHook(config parameterConfig) mapstructure.DecodeHookFuncType {
+	return func(from, to reflect.Type, data interface{}) (interface{}, error) {
+		if from.Kind() != reflect.Map || to.Kind() != reflect.Map || to.Key().Kind() == reflect.String {
+			return data, nil
+		}
+
+		dataValue := reflect.ValueOf(data)
+		converted := make(map[interface{}]interface{}, dataValue.Len())
+
+		iter := dataValue.MapRange()
+		for iter.Next() {
+			key := reflect.New(to.Key()).Elem()
+			if err := setFromString(key, fmt.Sprint(iter.Key().Interface()), config); err != nil {
+				return nil, err
+			}
+
+			converted[key.Interface()] = iter.Value().Interface()
+		}
+
+		return converted, nil
+	}
+}
+
+// jsonDecodeHook is a mapstructure decode hook for types that implement json.Unmarshaler,
+// the value is marshaled to JSON again and decoded with the type's UnmarshalJSON.
+// Types that implement encoding.TextUnmarshaler are decoded from strings by stringDecodeHook instead.
+func jsonDecodeHook(from, to reflect.Type, data interface{}) (interface{}, error) {
+	if !reflect.PtrTo(to).Implements(reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()) {
+		return data, nil
+	}
+
+	if _, ok := scalarString(data); ok && decodesFromString(to, from.Kind() == reflect.String) {
+		return data, nil
+	}
+
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	target := reflect.New(to)
+	if err := target.Interface().(json.Unmarshaler).UnmarshalJSON(jsonBytes); err != nil {
+		return nil, err
+	}
+
+	return target.Elem().Interface(), nil
+}
+
+// stringDecodeHook returns a mapstructure decode hook that converts scalar values with setFromString
+// if the target type is supported by decodesFromString.
+func stringDecodeHook(config parameterConfig) mapstructure.DecodeHookFuncType {
+	return func(from, to reflect.Type, data interface{}) (interface{}, error) {
+		value, ok := scalarString(data)
+		if !ok || !decodesFromString(to, from.Kind() == reflect.String) {
+			return data, nil
+		}
+
+		target := reflect.New(to).Elem()
+		if err := setFromString(target, value, config); err != nil {
+			return nil, err
+		}
+
+		return target.Interface(), nil
+	}
+}
+
+// decodesFromString returns true for types that mapstructure can't decode from scalar values but setFromString can,
+// slices and maps are only decoded from strings. Durations are handled by mapstructure's own hook, so numbers
+// are decoded as nanoseconds.
+func decodesFromString(t reflect.Type, isString bool) bool {
+	switch {
+	case t == reflect.TypeOf(time.Time{}):
+		return true
+	case reflect.PtrTo(t).Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()):
+		return true
+	default:
+		return isString && (t.Kind() == reflect.Slice || t.Kind() == reflect.Map)
+	}
+}
+
+// scalarString returns strings and numbers from decoded files as string.
+func scalarString(value interface{}) (string, bool) {
+	switch typedValue := value.(type) {
+	case string:
+		return typedValue, true
+	case int, int64, uint64:
+		return fmt.Sprint(typedValue), true
+	case float64:
+		return strconv.FormatFloat(typedValue, 'f', -1, 64), true
+	default:
+		return "", false
+	}
+}
+
+func getEnvAsMap() map[string]string {
+	envMap := map[string]string{}
+
+	envKeyVal := os.Environ()
+	for _, keyVal := range envKeyVal {
+		split := strings.SplitN(keyVal, "=", 2)
+		envMap[split[0]] = split[1]
+	}
+
+	return envMap
+}
+
+func (c *Collector) readEnv(fields []*field, vars map[string]string) error {
+	generatedNames := c.generatedEnvNames(fields)
+	owners := c.envNameOwners(fields, generatedNames)
+
+	for i, f := range fields {
+		envNames := []string{
+			c.explicitEnvName(f, vars),
+			generatedNames[i],
+		}
+
+		for _, envName := range envNames {
+			if envName == "" {
+				continue
+			}
+
+			envName = strings.ToUpper(envName)
+
+			if err := c.readEnvFile(f, envName, vars, owners); err != nil {
+				return err
+			}
+
+			envVal, ok := vars[envName]
+			if !ok {
+				continue
+			}
+
+			if c.Env.TrimSpace {
+				envVal = strings.TrimSpace(envVal)
+			}
+
+			value := envVal
+			if f.Config.Presence {
+				var err error
+				if value, err = presenceValue(f, envVal); err != nil {
+					return err
+				}
+			}
+
+			if err := c.setFromString(f, value); err != nil {
+				return err
+			}
+
+			c.record(f, SourceEnv, envName, envVal)
+		}
+
+		if err := c.readEnvIndices(f, vars); err != nil {
+			return err
+		}
+
+		if err := c.readEnvMapEntries(f, vars); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// presenceValue returns the value for a bool field with the presence key in the struct tag,
+// which is true for any value of the environment variable (including an empty one) unless it's a recognized false.
+func presenceValue(f *field, envVal string) (string, error) {
+	if f.Value.Kind() != reflect.Bool {
+		return "", fmt.Errorf("%w: presence is only supported for bools, not for %s", ErrUnsupportedType, f.FullName("."))
+	}
+
+	if isTrue, err := parseBool(envVal); err == nil && !isTrue {
+		return envVal, nil
+	}
+
+	return "true", nil
+}
+
+// readEnvFile sets the field from the file that the environment variable with the FileSuffix points to,
+// e.g. DB_PASSWORD_FILE=/run/secrets/pw for DB_PASSWORD.
+// Structs are skipped since they can't be set from a string anyway (e.g. LOG_FILE for the struct Log),
+// as well as file variables that are the name of another field (e.g. CERT_FILE for Cert if there is CertFile).
+func (c *Collector) readEnvFile(f *field, envName string, vars map[string]string, owners map[string]*field) error {
+	if c.Env.FileSuffix == "" || f.Value.Kind() == reflect.Struct && !isUnmarshaler(f.Value.Type()) {
+		return nil
+	}
+
+	fileEnvName := envName + strings.ToUpper(c.Env.FileSuffix)
+	if owner, ok := owners[fileEnvName]; ok && owner != f {
+		return nil
+	}
+
+	filePath, ok := vars[fileEnvName]
+	if !ok {
+		return nil
+	}
+
+	fileBytes, err := c.readFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	if err := c.setFromString(f, strings.TrimSpace(string(fileBytes))); err != nil {
+		return err
+	}
+
+	// the path is recorded instead of the content since it's probably a secret
+	c.record(f, SourceEnv, fileEnvName, filePath)
+
+	return nil
+}
+
+// envNameOwners returns the fields by their generated and explicit environment variable names
+// (including aliases). If multiple fields share a name the first one owns it.
+func (c *Collector) envNameOwners(fields []*field, generatedNames []string) map[string]*field {
+	owners := map[string]*field{}
+
+	for i, f := range fields {
+		names := []string{generatedNames[i]}
+		for _, name := range append([]string{f.Config.DefaultEnvName}, f.Config.EnvAliases...) {
+			if name != "" {
+				names = append(names, strings.ToUpper(c.prefixExplicitEnvName(name)))
+			}
+		}
+
+		for _, name := range names {
+			if _, ok := owners[name]; name != "" && !ok {
+				owners[name] = f
+			}
+		}
+	}
+
+	return owners
+}
+
+// prefixExplicitEnvName returns the environment variable name that is defined in the struct tag,
+// prefixed if configured. Names with the absolute marker (e.g. !HOME) are never prefixed.
+func (c *Collector) prefixExplicitEnvName(name string) string {
+	if strings.HasPrefix(name, absoluteEnvNameMarker) {
+		return strings.TrimPrefix(name, absoluteEnvNameMarker)
+	}
+
+	if !c.Env.PrefixExplicitNames || name == "" || c.envPrefix() == "" {
+		return name
+	}
+
+	return c.envPrefix() + c.Env.Separator + name
+}
+
+// generatedEnvNames returns the generated environment variable name of each field, indexed like fields.
+// If multiple fields result in the same generated name (e.g. Db_Host and Db.Host), the fields that define
+// an explicit name in the struct tag don't use the generated name, so they can be set unambiguously.
+func (c *Collector) generatedEnvNames(fields []*field) []string {
+	names := make([]string, len(fields))
+	counts := map[string]int{}
+
+	for i, f := range fields {
+		names[i] = c.distinctEnvName(f)
+		counts[names[i]]++
+	}
+
+	for i, f := range fields {
+		if counts[names[i]] > 1 && f.Config.DefaultEnvName != "" {
+			names[i] = ""
+		}
+	}
+
+	return names
+}
+
+// distinctEnvName returns the environment variable name that is generated for the field.
+func (c *Collector) distinctEnvName(f *field) string {
+	naming := c.Env.Naming
+	if naming == nil && c.Env.SnakeCase {
+		naming = SnakeCase
+	}
+
+	envName := f.nameWith(c.Env.Separator, naming)
+	if prefix := c.envPrefix(); prefix != "" {
+		envName = prefix + c.Env.Separator + envName
+	}
+
+	return strings.ToUpper(envName)
+}
+
+// fileFieldName returns the key that is generated for the field in config files.
+func (c *Collector) fileFieldName(f *field) string {
+	return f.fileNameWith(c.Files.Separator, c.Files.Naming)
+}
+
+// fileNameWith returns the field's key in config files like nameWith, but the names from the json or yaml
+// struct tags of the field and its parents are used as they are instead of the Go field names.
+func (f *field) fileNameWith(separator string, naming NamingStrategy) string {
+	names := append(append([]string{}, f.Base...), f.Name)
+
+	for i, name := range names {
+		switch {
+		case i < len(f.FileNames) && f.FileNames[i] != "":
+			names[i] = f.FileNames[i]
+		case naming != nil:
+			names[i] = naming(name)
+		}
+	}
+
+	return strings.Join(names, separator)
+}
+
+// tagFileName returns the name from the json struct tag or, if there is none, from the yaml struct tag.
+// Options like omitempty and the name "-" are ignored, so the field can still be set from files.
+func tagFileName(structTag reflect.StructTag) string {
+	for _, key := range []string{"json", "yaml"} {
+		name := strings.Split(structTag.Get(key), ",")[0]
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// longFlagName returns the long flag name that is generated for the field.
+func (c *Collector) longFlagName(f *field) string {
+	longName := f.nameWith(c.Flags.Separator, c.Flags.Naming)
+	if prefix := c.flagPrefix(); prefix != "" {
+		longName = prefix + c.Flags.Separator + longName
+	}
+
+	return strings.ToLower(longName)
+}
+
+// checkDuplicateNames returns an error if multiple fields result in the same generated environment variable
+// or flag name, or if the same flag shorthand is defined for multiple fields.
+// Names that are defined in the struct tags can intentionally be shared between fields,
+// but the generated flag names must not collide with them since the flags can't be distinguished.
+func (c *Collector) checkDuplicateNames(fields []*field) error {
+	if !c.Env.Disabled {
+		envNames := map[string]*field{}
+
+		for i, name := range c.generatedEnvNames(fields) {
+			if name == "" {
+				continue
+			}
+
+			if err := checkDuplicateName(envNames, "environment variable", name, fields[i]); err != nil {
+				return err
+			}
+		}
+
+		if c.Env.UniqueNames {
+			explicitNames := map[string]*field{}
+
+			for _, f := range fields {
+				names := append([]string{f.Config.DefaultEnvName}, f.Config.EnvAliases...)
+				for i := range names {
+					names[i] = strings.ToUpper(c.prefixExplicitEnvName(names[i]))
+				}
+
+				if err := checkDuplicateExplicitNames(explicitNames, "environment variable", names, f); err != nil {
+					return err
+				}
+			}
+
+			if err := c.checkEnvFileNames(fields); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !c.Files.Disabled && c.Files.UniqueNames {
+		explicitKeys := map[string]*field{}
+
+		for _, f := range fields {
+			keys := append([]string{f.Config.DefaultFileField}, f.Config.FileAliases...)
+			if err := checkDuplicateExplicitNames(explicitKeys, "file key", keys, f); err != nil {
+				return err
+			}
+		}
+	}
+
+	if c.Flags.Disabled {
+		return nil
+	}
+
+	defaultNames := map[string]*field{}
+
+	for _, f := range fields {
+		if name := f.Config.Flag.DefaultName; name != "" && defaultNames[name] == nil {
+			defaultNames[name] = f
+		}
+	}
+
+	flagNames := map[string]*field{}
+	shorthands := map[string]*field{}
+
+	for _, f := range fields {
+		if c.generatesLongFlag(f) {
+			longName := c.longFlagName(f)
+			if err := checkDuplicateName(defaultNames, "flag", longName, f); err != nil {
+				return err
+			}
+
+			if err := checkDuplicateName(flagNames, "flag", longName, f); err != nil {
+				return err
+			}
+		}
+
+		if f.Config.Flag.ShortName == "" {
+			continue
+		}
+
+		if err := checkDuplicateName(shorthands, "flag shorthand", f.Config.Flag.ShortName, f); err != nil {
+			return err
+		}
+	}
+
+	if name := c.Files.PathFlag; name != "" {
+		for _, names := range []map[string]*field{defaultNames, flagNames} {
+			if other, ok := names[name]; ok {
+				return fmt.Errorf("%w: flag %q for the config file path is already used by %s",
+					ErrDuplicateName, name, other.FullName("."))
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkDuplicateExplicitNames checks the names that are defined in the struct tag of f, empty names are skipped.
+func checkDuplicateExplicitNames(names map[string]*field, kind string, explicitNames []string, f *field) error {
+	for _, name := range explicitNames {
+		if name == "" {
+			continue
+		}
+
+		if err := checkDuplicateName(names, "explicit "+kind, name, f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkEnvFileNames returns ErrDuplicateName if the file variable of a field (see EnvConfig.FileSuffix)
+// is the environment variable name of another field, e.g. CERT_FILE of Cert and CertFile.
+func (c *Collector) checkEnvFileNames(fields []*field) error {
+	if c.Env.FileSuffix == "" {
+		return nil
+	}
+
+	generatedNames := c.generatedEnvNames(fields)
+	owners := c.envNameOwners(fields, generatedNames)
+
+	names := make([]string, 0, len(owners))
+	for name := range owners {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		f := owners[name]
+		if f.Value.Kind() == reflect.Struct && !isUnmarshaler(f.Value.Type()) {
+			continue
+		}
+
+		fileEnvName := name + strings.ToUpper(c.Env.FileSuffix)
+		if other, ok := owners[fileEnvName]; ok && other != f {
+			return fmt.Errorf(
+				"%w: file variable %q of %s is already used by %s",
+				ErrDuplicateName, fileEnvName, f.FullName("."), other.FullName("."),
+			)
+		}
+	}
+
+	return nil
+}
+
+func checkDuplicateName(names map[string]*field, kind, name string, f *field) error {
+	// a field can use the same name multiple times, e.g. if the name in the struct tag equals the generated one
+	if other, ok := names[name]; ok && other != f {
+		return fmt.Errorf(
+			"%w: %s %q of %s is already used by %s",
+			ErrDuplicateName, kind, name, f.FullName("."), other.FullName("."),
+		)
+	}
+
+	names[name] = f
+
+	return nil
+}
+
+// readPFlags parses the args and sets the fields from the flags that are set.
+// The fields are processed in declaration order, parents before their children, and for each field the flag with
+// the default name from the struct tag is applied before the flag with the generated long name.
+// So the generated flag always takes precedence, regardless of the order of the args.
+func (c *Collector) readPFlags(fields []*field, args []string) error {
+	flagSet, fieldToFlagNames := c.newPFlagSet(fields)
+
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	for i, f := range fields {
+		for _, name := range fieldToFlagNames[i] {
+			fieldFlag := flagSet.Lookup(name)
 
-	for f, flagInfoSlice := range fieldToFlagInfo {
-		for _, flagInfo := range flagInfoSlice {
 			// differentiate a flag that is not set from a flag that is set to ""
-			if !flagInfo.flag.Changed {
+			if !fieldFlag.Changed {
 				continue
 			}
 
-			if err := setFromString(f.Value, *flagInfo.valueStr); err != nil {
+			values := []string{fieldFlag.Value.String()}
+			if sliceValue, ok := fieldFlag.Value.(pflag.SliceValue); ok {
+				values = sliceValue.GetSlice()
+			}
+
+			if err := c.setFromFlagValues(f, values); err != nil {
 				return err
 			}
+
+			c.record(f, SourceFlag, fieldFlag.Name, fieldFlag.Value.String())
+		}
+	}
+
+	return nil
+}
+
+// newPFlagSet returns a flag set with the flags of all fields and the names of each field's flags, indexed like fields.
+func (c *Collector) newPFlagSet(fields []*field) (*pflag.FlagSet, [][]string) {
+	flagSet := pflag.NewFlagSet("config", pflag.ContinueOnError)
+	flagSet.ParseErrorsWhitelist = pflag.ParseErrorsWhitelist{UnknownFlags: true}
+
+	fieldToFlagNames := c.mapFlags(fields, func(f *field, name, shorthand, usage string) {
+		registerFlag(flagSet, f, name, shorthand, usage)
+	})
+
+	if c.Files.PathFlag != "" {
+		flagSet.String(c.Files.PathFlag, "", pathFlagUsage)
+	}
+
+	registerNegatedFlags(flagSet, fields, fieldToFlagNames)
+
+	return flagSet, fieldToFlagNames
+}
+
+// registerNegatedFlags registers a negated flag (e.g. --no-verbose) for each flag of the bool fields.
+// They're registered after all other flags and skipped if the name is already used,
+// so the flags of other fields (e.g. --no-cache of NoCache) take precedence over the negated ones.
+func registerNegatedFlags(flagSet *pflag.FlagSet, fields []*field, fieldToFlagNames [][]string) {
+	for i, f := range fields {
+		if f.Value.Kind() != reflect.Bool {
+			continue
+		}
+
+		for _, name := range fieldToFlagNames[i] {
+			negatedName := negatedFlagPrefix + name
+			if flagSet.Lookup(negatedName) != nil {
+				continue
+			}
+
+			negated := flagSet.VarPF(&negatedBoolValue{target: flagSet.Lookup(name)}, negatedName, "", "negates --"+name)
+			negated.NoOptDefVal = "true"
+		}
+	}
+}
+
+// mapFlags calls register for the flags of all fields and returns the names of each field's flags,
+// indexed like fields. The flag with the default name from the struct tag is registered only once if it is shared
+// by multiple fields, the flag with the generated long name is registered for every field with the shorthand.
+// The usage of the flags is the field path followed by the field's env variable if env variables are enabled.
+func (c *Collector) mapFlags(fields []*field, register func(f *field, name, shorthand, usage string)) [][]string {
+	fieldToFlagNames := make([][]string, len(fields))
+	registered := map[string]bool{}
+
+	var generatedEnvNames []string
+	if !c.Env.Disabled {
+		generatedEnvNames = c.generatedEnvNames(fields)
+	}
+
+	for i, f := range fields {
+		// the usage contains the field path and the env variable that can be used instead of the flag
+		usage := f.FullName(".")
+		if generatedEnvNames != nil {
+			if envNames := c.describeEnvNames(f, generatedEnvNames[i]); len(envNames) > 0 {
+				usage += " (env " + envNames[0] + ")"
+			}
+		}
+
+		longName := ""
+		if c.generatesLongFlag(f) {
+			longName = c.longFlagName(f)
+		}
+
+		// the flag with the default name is only registered if a name is defined in the struct tag
+		// that differs from the generated one
+		if defaultName := f.Config.Flag.DefaultName; defaultName != "" && defaultName != longName {
+			if !registered[defaultName] {
+				shorthand := ""
+				if !c.generatesLongFlag(f) {
+					shorthand = f.Config.Flag.ShortName
+				}
+
+				register(f, defaultName, shorthand, usage)
+				registered[defaultName] = true
+			}
+
+			fieldToFlagNames[i] = append(fieldToFlagNames[i], defaultName)
+		}
+
+		if longName == "" {
+			continue
+		}
+
+		register(f, longName, f.Config.Flag.ShortName, usage)
+
+		fieldToFlagNames[i] = append(fieldToFlagNames[i], longName)
+	}
+
+	return fieldToFlagNames
+}
+
+// generatesLongFlag returns true if the flag with the generated long name is registered for the field.
+// With OnlyTagged it's only registered for fields that define a shorthand but no name in the struct tag,
+// since a shorthand can't be registered without a long name.
+func (c *Collector) generatesLongFlag(f *field) bool {
+	return !c.Flags.OnlyTagged || f.Config.Flag.DefaultName == "" && f.Config.Flag.ShortName != ""
+}
+
+// registerFlag registers a flag for the field in the flagSet.
+// Fields of kind bool are registered as bool flags so that they can be set without a value (e.g. --verbose),
+// their negated flags (e.g. --no-verbose) are registered by registerNegatedFlags,
+// slice fields are registered as string array flags so that they can be repeated,
+// int fields with the count key in the struct tag are registered as count flags (e.g. -vvv),
+// all others are registered as string flags and converted with setFromString.
+// The current value of the field is used as default value so that it's shown in the usage,
+// it doesn't affect the parsing since only flags that are set are applied.
+func registerFlag(flagSet *pflag.FlagSet, f *field, name, shorthand, usage string) *pflag.Flag {
+	switch {
+	case f.Value.Kind() == reflect.Bool:
+		flagSet.BoolP(name, shorthand, f.Value.IsValid() && f.Value.Bool(), usage)
+	case f.Config.Count && f.Value.Kind() == reflect.Int:
+		flagSet.CountP(name, shorthand, usage)
+	case f.Value.IsValid() && isRepeatable(f.Value.Type()):
+		flagSet.StringArrayP(name, shorthand, flagDefaults(f), usage)
+	default:
+		flagSet.StringP(name, shorthand, flagDefault(f), usage)
+	}
+
+	return flagSet.Lookup(name)
+}
+
+// negatedBoolValue is the value of a negated bool flag, setting it sets the target flag to the opposite value.
+// Since both flags share the target's value the last one that is set wins.
+type negatedBoolValue struct {
+	target *pflag.Flag
+	value  bool
+}
+
+func (v *negatedBoolValue) Set(s string) error {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+
+	if err := v.target.Value.Set(strconv.FormatBool(!b)); err != nil {
+		return err
+	}
+
+	v.value = b
+	v.target.Changed = true
+
+	return nil
+}
+
+func (v *negatedBoolValue) String() string {
+	return strconv.FormatBool(v.value)
+}
+
+func (v *negatedBoolValue) Type() string {
+	return "bool"
+}
+
+// isUnmarshaler returns true for types that implement encoding.TextUnmarshaler or json.Unmarshaler
+// with a value or pointer receiver. Structs like that (e.g. time.Time) are decoded as a whole
+// and no fields are generated for their children.
+func isUnmarshaler(t reflect.Type) bool {
+	return reflect.PtrTo(t).Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()) ||
+		reflect.PtrTo(t).Implements(reflect.TypeOf((*json.Unmarshaler)(nil)).Elem())
+}
+
+// isRepeatable returns true for slice types that can be set from repeated flags.
+// Byte slices (e.g. []byte or net.IP) and slices implementing encoding.TextUnmarshaler are decoded as a whole
+// and therefore excluded.
+func isRepeatable(t reflect.Type) bool {
+	if t.Kind() != reflect.Slice || t.Elem().Kind() == reflect.Uint8 {
+		return false
+	}
+
+	return !reflect.PtrTo(t).Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem())
+}
+
+// setFromFlagValues sets the field's value from the values of a flag.
+// For repeated flags of slice fields the elements of all values are appended to a single slice,
+// for all other fields the last value is used.
+func (c *Collector) setFromFlagValues(f *field, values []string) error {
+	// the flag can be shared with a field that is not repeatable if they use the same default name,
+	// invalid values of nil pointers result in ErrCantSet
+	if !f.Value.IsValid() || !isRepeatable(f.Value.Type()) {
+		return c.setFromString(f, values[len(values)-1])
+	}
+
+	config := f.Config
+	config.Converters = c.converters
+
+	slice := reflect.MakeSlice(f.Value.Type(), 0, len(values))
+
+	for _, value := range values {
+		elements := reflect.New(f.Value.Type()).Elem()
+		if err := setFromString(elements, value, config); err != nil {
+			return err
 		}
+
+		slice = reflect.AppendSlice(slice, elements)
 	}
 
+	f.Value.Set(slice)
+
 	return nil
 }
 
-func setFromString(target reflect.Value, value string) (err error) { // nolint: funlen,gocyclo // just huge switch case
+// DecodeString converts the value to the type of the variable target points to and sets it,
+// with the same rules that are used by Collector.Get for environment variables and flags without any
+// keys in the struct tag, e.g. duration strings, yes/no for booleans, hexadecimal integers and lists
+// in the format val1,val2,val3. It returns ErrPointerExpected if target is not a pointer and ErrNilPointer
+// if it's nil. Converters registered with Collector.RegisterConverter are not used.
+func DecodeString(target interface{}, value string) error {
+	targetValue := reflect.ValueOf(target)
+	if err := checkPointer(targetValue); err != nil {
+		return err
+	}
+
+	return setFromString(targetValue.Elem(), value, parameterConfig{})
+}
+
+func setFromString(target reflect.Value, value string, config parameterConfig) (err error) { // nolint: funlen,gocyclo // just huge switch case
 	defer func() {
 		if e := recover(); e != nil {
 			err = ErrUnsupportedType
@@ -461,18 +1989,44 @@ func setFromString(target reflect.Value, value string) (err error) { // nolint:
 		return ErrCantSet
 	}
 
+	// an empty value explicitly sets an empty slice or map instead of nil, all other types are set to zero
 	if value == "" {
-		zeroValue := reflect.Zero(target.Type())
-		target.Set(zeroValue)
+		switch target.Kind() {
+		case reflect.Slice:
+			target.Set(reflect.MakeSlice(target.Type(), 0, 0))
+		case reflect.Map:
+			target.Set(reflect.MakeMap(target.Type()))
+		default:
+			target.Set(reflect.Zero(target.Type()))
+		}
 
 		return nil
 	}
 
+	if ok, err := convert(target, value, config); ok {
+		return err
+	}
+
+	if config.JSON {
+		return json.Unmarshal([]byte(value), target.Addr().Interface())
+	}
+
 	var valToSet interface{}
 
 	switch target.Interface().(type) {
 	case int, int8, int16, int32, int64:
-		intVal, err := strconv.ParseInt(value, 10, 0)
+		if config.ByteSize {
+			size, err := parseByteSize(value, target.Type().Bits()-1)
+			if err != nil {
+				return err
+			}
+
+			target.SetInt(int64(size))
+
+			return nil
+		}
+
+		intVal, err := strconv.ParseInt(value, 0, target.Type().Bits())
 		if err != nil {
 			return err
 		}
@@ -481,7 +2035,7 @@ func setFromString(target reflect.Value, value string) (err error) { // nolint:
 
 		return nil
 	case complex64, complex128:
-		complexVal, err := strconv.ParseComplex(value, 0)
+		complexVal, err := strconv.ParseComplex(value, target.Type().Bits())
 		if err != nil {
 			return err
 		}
@@ -490,7 +2044,18 @@ func setFromString(target reflect.Value, value string) (err error) { // nolint:
 
 		return nil
 	case uint, uint8, uint16, uint32, uint64:
-		uintVal, err := strconv.ParseUint(value, 10, 0)
+		if config.ByteSize {
+			size, err := parseByteSize(value, target.Type().Bits())
+			if err != nil {
+				return err
+			}
+
+			target.SetUint(size)
+
+			return nil
+		}
+
+		uintVal, err := strconv.ParseUint(value, 0, target.Type().Bits())
 		if err != nil {
 			return err
 		}
@@ -499,7 +2064,7 @@ func setFromString(target reflect.Value, value string) (err error) { // nolint:
 
 		return nil
 	case float32, float64:
-		floatVal, err := strconv.ParseFloat(value, 0)
+		floatVal, err := strconv.ParseFloat(value, target.Type().Bits())
 		if err != nil {
 			return err
 		}
@@ -510,19 +2075,26 @@ func setFromString(target reflect.Value, value string) (err error) { // nolint:
 	case time.Duration:
 		valToSet, err = time.ParseDuration(value)
 	case time.Time:
-		valToSet, err = time.Parse(time.RFC3339, value)
+		valToSet, err = parseTime(value, config.TimeFormat)
 	case bool:
-		valToSet, err = strconv.ParseBool(value)
+		valToSet, err = parseBool(value)
 	case string:
 		valToSet = value
+	case []byte:
+		encoding := config.Base64Encoding
+		if encoding == nil {
+			encoding = base64.StdEncoding
+		}
+
+		valToSet, err = encoding.DecodeString(value)
 	case []string:
 		strSlice := stringSlice{}
-		_ = strSlice.UnmarshalText([]byte(value))
+		err = strSlice.unmarshalText([]byte(value), config.listSeparator())
 
 		valToSet = []string(strSlice)
 	case map[string]string:
 		strMap := stringMap{}
-		_ = strMap.UnmarshalText([]byte(value))
+		err = strMap.unmarshalText([]byte(value), config.listSeparator(), config.keyValueSeparator())
 
 		valToSet = map[string]string(strMap)
 	case encoding.TextUnmarshaler:
@@ -533,6 +2105,19 @@ func setFromString(target reflect.Value, value string) (err error) { // nolint:
 			return t.UnmarshalText([]byte(value))
 		}
 
+		// fall back to BinaryUnmarshaler for types that don't implement TextUnmarshaler
+		if b, ok := target.Addr().Interface().(encoding.BinaryUnmarshaler); ok {
+			return b.UnmarshalBinary([]byte(value))
+		}
+
+		if target.Kind() == reflect.Slice {
+			return setSliceFromString(target, value, config)
+		}
+
+		if target.Kind() == reflect.Map {
+			return setMapFromString(target, value, config)
+		}
+
 		valToSet = value
 	}
 
@@ -545,9 +2130,58 @@ func setFromString(target reflect.Value, value string) (err error) { // nolint:
 	return nil
 }
 
+// setSliceFromString splits the value into its elements and sets each of them with setFromString,
+// so all types that are supported by setFromString (including TextUnmarshaler implementations)
+// are supported as slice elements as well.
+func setSliceFromString(target reflect.Value, value string, config parameterConfig) error {
+	elements, err := splitList(value, config.listSeparator())
+	if err != nil {
+		return err
+	}
+
+	slice := reflect.MakeSlice(target.Type(), len(elements), len(elements))
+	for i, element := range elements {
+		if err := setFromString(slice.Index(i), element, config); err != nil {
+			return fmt.Errorf("element %d of %q: %w", i, value, err)
+		}
+	}
+
+	target.Set(slice)
+
+	return nil
+}
+
+// setMapFromString splits the value into its entries and sets the keys and values of each entry
+// with setFromString, so maps with other key and value types than string (e.g. map[int]string) are supported.
+func setMapFromString(target reflect.Value, value string, config parameterConfig) error {
+	entries := stringMap{}
+	if err := entries.unmarshalText([]byte(value), config.listSeparator(), config.keyValueSeparator()); err != nil {
+		return err
+	}
+
+	m := reflect.MakeMapWithSize(target.Type(), len(entries))
+	for k, v := range entries {
+		key := reflect.New(target.Type().Key()).Elem()
+		if err := setFromString(key, k, config); err != nil {
+			return err
+		}
+
+		val := reflect.New(target.Type().Elem()).Elem()
+		if err := setFromString(val, v, config); err != nil {
+			return err
+		}
+
+		m.SetMapIndex(key, val)
+	}
+
+	target.Set(m)
+
+	return nil
+}
+
 func unmarshal(fileSeparator string, bytes []byte) (*ciMap, error) {
 	m := newCiMap(withSeparator(fileSeparator))
-	if err := yaml.Unmarshal(bytes, m); err == nil {
+	if err := m.unmarshalYAMLDocuments(bytes); err == nil {
 		return m, nil
 	}
 
@@ -555,9 +2189,97 @@ func unmarshal(fileSeparator string, bytes []byte) (*ciMap, error) {
 		return m, nil
 	}
 
+	if err := m.UnmarshalINI(bytes); err == nil {
+		return m, nil
+	}
+
 	return nil, ErrFileTypeNotSupported
 }
 
+// parseBool parses yes, no, on and off (case insensitive) in addition to the values supported by strconv.ParseBool.
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "yes", "on":
+		return true, nil
+	case "no", "off":
+		return false, nil
+	default:
+		return strconv.ParseBool(value)
+	}
+}
+
+// parseTime parses the value as RFC3339 timestamp or date, as Unix epoch for the timeformat values unix and unixmilli
+// or with any other timeformat as layout.
+func parseTime(value, timeFormat string) (time.Time, error) {
+	switch timeFormat {
+	case "":
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			if date, dateErr := time.Parse(dateLayout, value); dateErr == nil {
+				return date, nil
+			}
+		}
+
+		return t, err
+	case timeFormatUnix, timeFormatUnixMilli:
+	default:
+		return time.Parse(timeFormat, value)
+	}
+
+	epoch, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if timeFormat == timeFormatUnixMilli {
+		return time.Unix(epoch/1000, epoch%1000*int64(time.Millisecond)), nil
+	}
+
+	return time.Unix(epoch, 0), nil
+}
+
+// isTimeLayout returns true if layout contains elements of the reference time (e.g. 2006 or Jan) and a time formatted
+// with it can be parsed again. Layouts without any elements are just literal text and would format all times the same.
+func isTimeLayout(layout string) bool {
+	formatted := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC).Format(layout)
+	if formatted == layout {
+		return false
+	}
+
+	_, err := time.Parse(layout, formatted)
+
+	return err == nil
+}
+
+// readBase64Encoding returns the base64 encoding for the value of the base64 struct tag key.
+func readBase64Encoding(encodingStr string) (*base64.Encoding, error) {
+	switch encodingStr {
+	case "std":
+		return base64.StdEncoding, nil
+	case "raw":
+		return base64.RawStdEncoding, nil
+	case "url":
+		return base64.URLEncoding, nil
+	case "rawurl":
+		return base64.RawURLEncoding, nil
+	default:
+		return nil, ErrUnknownEncoding
+	}
+}
+
+// ParseFlagTag parses the value of the flag key in a config struct tag, e.g. "p port" of `config:"flag=p port"`,
+// with the same rules that are used by Collector.Get and returns the long and short flag names.
+// This can be used by tools that document the flags of a config struct. It returns ErrMalformedFlagConfig
+// if the value is invalid.
+func ParseFlagTag(flagStr string) (long, short string, err error) {
+	flagConf, err := readFlagConfig(flagStr)
+	if err != nil {
+		return "", "", err
+	}
+
+	return flagConf.DefaultName, flagConf.ShortName, nil
+}
+
 func readFlagConfig(flagStr string) (flag, error) {
 	flagConf := flag{}
 	flags := strings.Split(flagStr, flagConfigSeparator)
@@ -567,7 +2289,27 @@ func readFlagConfig(flagStr string) (flag, error) {
 	}
 
 	for _, f := range flags {
-		if len([]rune(f)) == 1 {
+		// names can be marked explicitly with short: and long:, otherwise single runes are short names
+		isShort := len([]rune(f)) == 1
+
+		switch {
+		case strings.HasPrefix(f, flagShortPrefix):
+			f = strings.TrimPrefix(f, flagShortPrefix)
+			if len([]rune(f)) != 1 {
+				return flag{}, ErrMalformedFlagConfig
+			}
+
+			isShort = true
+		case strings.HasPrefix(f, flagLongPrefix):
+			f = strings.TrimPrefix(f, flagLongPrefix)
+			if f == "" {
+				return flag{}, ErrMalformedFlagConfig
+			}
+
+			isShort = false
+		}
+
+		if isShort {
 			if flagConf.ShortName != "" {
 				return flag{}, ErrMalformedFlagConfig
 			}
@@ -588,11 +2330,21 @@ func readFlagConfig(flagStr string) (flag, error) {
 type stringMap map[string]string
 
 func (m stringMap) UnmarshalText(text []byte) error {
+	return m.unmarshalText(text, defaultListSeparator, defaultKeyValueSeparator)
+}
+
+func (m stringMap) unmarshalText(text []byte, separator, keyValueSeparator string) error {
 	keyVals := stringSlice{}
-	_ = keyVals.UnmarshalText(text)
+	if err := keyVals.unmarshalText(text, separator); err != nil {
+		return err
+	}
 
 	for _, keyVal := range keyVals {
-		split := strings.SplitN(keyVal, "=", 2)
+		split := strings.SplitN(keyVal, keyValueSeparator, 2)
+		if len(split) != 2 {
+			return fmt.Errorf("%w: %q", ErrMalformedMapEntry, keyVal)
+		}
+
 		for i := range split {
 			split[i] = strings.TrimSpace(split[i])
 		}
@@ -603,10 +2355,18 @@ func (m stringMap) UnmarshalText(text []byte) error {
 	return nil
 }
 
+// MarshalText returns the entries sorted by key, so the output is deterministic.
 func (m stringMap) MarshalText() ([]byte, error) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
 	keyVals := make([]string, 0, len(m))
-	for k, v := range m {
-		keyVals = append(keyVals, strings.Join([]string{k, v}, "="))
+	for _, k := range keys {
+		keyVals = append(keyVals, strings.Join([]string{k, m[k]}, defaultKeyValueSeparator))
 	}
 
 	return stringSlice(keyVals).MarshalText()
@@ -615,9 +2375,13 @@ func (m stringMap) MarshalText() ([]byte, error) {
 type stringSlice []string
 
 func (s *stringSlice) UnmarshalText(text []byte) error {
-	tmpSlice := strings.Split(string(text), ",")
-	for i := range tmpSlice {
-		tmpSlice[i] = strings.TrimSpace(tmpSlice[i])
+	return s.unmarshalText(text, defaultListSeparator)
+}
+
+func (s *stringSlice) unmarshalText(text []byte, separator string) error {
+	tmpSlice, err := splitList(string(text), separator)
+	if err != nil {
+		return err
 	}
 
 	*s = tmpSlice
@@ -626,5 +2390,78 @@ func (s *stringSlice) UnmarshalText(text []byte) error {
 }
 
 func (s stringSlice) MarshalText() ([]byte, error) {
-	return []byte(strings.Join(s, ",")), nil
+	return []byte(strings.Join(s, defaultListSeparator)), nil
+}
+
+// splitList splits text into its elements by the separator.
+// Similar to csv, elements can be enclosed in double quotes to contain the separator and
+// quotes inside of quoted elements are escaped by doubling them ("").
+// Whitespace around unquoted elements is trimmed, quoted elements are kept as they are.
+func splitList(text, separator string) ([]string, error) {
+	var elements []string
+
+	rest := text
+
+	for {
+		trimmed := strings.TrimLeftFunc(rest, unicode.IsSpace)
+		if !strings.HasPrefix(trimmed, `"`) {
+			idx := strings.Index(rest, separator)
+			if idx == -1 {
+				return append(elements, strings.TrimSpace(rest)), nil
+			}
+
+			elements = append(elements, strings.TrimSpace(rest[:idx]))
+			rest = rest[idx+len(separator):]
+
+			continue
+		}
+
+		element, remainder, err := readQuoted(trimmed[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		elements = append(elements, element)
+
+		// only whitespace is allowed between the closing quote and the next separator
+		for !strings.HasPrefix(remainder, separator) {
+			if remainder == "" {
+				return elements, nil
+			}
+
+			r, size := utf8.DecodeRuneInString(remainder)
+			if !unicode.IsSpace(r) {
+				return nil, fmt.Errorf("%w: unexpected %q after closing quote", ErrMalformedList, r)
+			}
+
+			remainder = remainder[size:]
+		}
+
+		rest = remainder[len(separator):]
+	}
+}
+
+// readQuoted reads a quoted element until the closing quote and returns it
+// together with the remainder of the string after the closing quote.
+func readQuoted(s string) (element, remainder string, err error) {
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '"' {
+			b.WriteByte(s[i])
+
+			continue
+		}
+
+		if i+1 < len(s) && s[i+1] == '"' {
+			b.WriteByte('"')
+			i++
+
+			continue
+		}
+
+		return b.String(), s[i+1:], nil
+	}
+
+	return "", "", fmt.Errorf("%w: missing closing quote", ErrMalformedList)
 }
@@ -1,10 +1,19 @@
 package alligotor
 
 import (
-	"io/ioutil"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
 	"os"
 	"path"
+	"path/filepath"
 	"reflect"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/brumhard/alligotor/test"
@@ -20,6 +29,12 @@ var _ = Describe("config", func() {
 				Expect(err).Should(HaveOccurred())
 				Expect(err).To(Equal(ErrMalformedFlagConfig))
 			})
+			It("should return error for invalid explicit names", func() {
+				for _, configStr := range []string{"short:pp", "short:", "long:", "long:x long:y", "short:p q"} {
+					_, err := readFlagConfig(configStr)
+					Expect(err).To(Equal(ErrMalformedFlagConfig), configStr)
+				}
+			})
 			It("should return error if longname has less than 2 letters", func() {
 				for _, configStr := range []string{"a b", "long long"} {
 					_, err := readFlagConfig(configStr)
@@ -46,6 +61,81 @@ var _ = Describe("config", func() {
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(f).To(Equal(flag{ShortName: "", DefaultName: "awd"}))
 			})
+			It("should support explicit short and long names", func() {
+				f, err := readFlagConfig("long:x")
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(f).To(Equal(flag{DefaultName: "x"}))
+
+				f, err = readFlagConfig("short:p long:x")
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(f).To(Equal(flag{ShortName: "p", DefaultName: "x"}))
+
+				f, err = readFlagConfig("short:p port")
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(f).To(Equal(flag{ShortName: "p", DefaultName: "port"}))
+			})
+		})
+	})
+	Describe("ParseFlagTag", func() {
+		It("returns long and short names", func() {
+			long, short, err := ParseFlagTag("p port")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(long).To(Equal("port"))
+			Expect(short).To(Equal("p"))
+		})
+		It("returns error for malformed values", func() {
+			_, _, err := ParseFlagTag("a b")
+			Expect(err).To(Equal(ErrMalformedFlagConfig))
+		})
+	})
+	Describe("DecodeString", func() {
+		It("converts the value like Get", func() {
+			var d time.Duration
+			Expect(DecodeString(&d, "5s")).To(Succeed())
+			Expect(d).To(Equal(5 * time.Second))
+
+			var b bool
+			Expect(DecodeString(&b, "yes")).To(Succeed())
+			Expect(b).To(BeTrue())
+
+			var ints []int
+			Expect(DecodeString(&ints, "0x10,2")).To(Succeed())
+			Expect(ints).To(Equal([]int{16, 2}))
+		})
+		It("returns conversion errors", func() {
+			var i int
+			Expect(DecodeString(&i, "abc")).ToNot(Succeed())
+		})
+		It("supports math/big types", func() {
+			i := new(big.Int)
+			Expect(DecodeString(i, "0xFF")).To(Succeed())
+			Expect(i.Int64()).To(Equal(int64(255)))
+			Expect(DecodeString(i, "123456789012345678901234567890")).To(Succeed())
+			Expect(i.String()).To(Equal("123456789012345678901234567890"))
+			Expect(DecodeString(i, "12a")).ToNot(Succeed())
+
+			f := new(big.Float)
+			Expect(DecodeString(f, "1.5e3")).To(Succeed())
+			Expect(f.String()).To(Equal("1500"))
+			Expect(DecodeString(f, "1.5x")).ToNot(Succeed())
+
+			cfg := struct {
+				Int   *big.Int
+				Float big.Float
+			}{}
+			c := &Collector{
+				Files: FilesConfig{Disabled: true},
+				Env:   EnvConfig{Disabled: true},
+				Flags: FlagsConfig{Separator: "-", Args: []string{"--int", "0b101", "--float", "2.5"}},
+			}
+			Expect(c.Get(&cfg)).To(Succeed())
+			Expect(cfg.Int.Int64()).To(Equal(int64(5)))
+			Expect(cfg.Float.String()).To(Equal("2.5"))
+		})
+		It("returns error if target is no pointer", func() {
+			var i int
+			Expect(DecodeString(i, "1")).To(Equal(ErrPointerExpected))
+			Expect(DecodeString((*int)(nil), "1")).To(Equal(ErrNilPointer))
 		})
 	})
 	Describe("unmarshal", func() {
@@ -63,6 +153,30 @@ test:
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(yamlMap.m).To(Equal(expectedMap))
 			})
+			It("resolves anchors, aliases and merge keys", func() {
+				yamlBytes := []byte(`---
+base: &base
+  host: localhost
+  port: 1
+  tls: &tls
+    enabled: true
+test:
+  <<: *base
+  port: 2
+  tls: *tls
+`)
+				yamlMap, err := unmarshal(defaultFileSeparator, yamlBytes)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(yamlMap.m["test"]).To(Equal(map[string]interface{}{
+					"host": "localhost",
+					"port": 2,
+					"tls":  map[string]interface{}{"enabled": true},
+				}))
+
+				val, ok := yamlMap.Get("test.tls.enabled")
+				Expect(ok).To(BeTrue())
+				Expect(val).To(BeTrue())
+			})
 		})
 		Context("json", func() {
 			It("should succeed with valid input", func() {
@@ -72,6 +186,32 @@ test:
 				Expect(jsonMap.m).To(Equal(expectedMap))
 			})
 		})
+		Context("ini", func() {
+			It("should succeed with valid input", func() {
+				iniBytes := []byte(`[test]
+sub = lel
+`)
+				iniMap, err := unmarshal(defaultFileSeparator, iniBytes)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(iniMap.m).To(Equal(expectedMap))
+			})
+			It("maps keys outside of sections to the root, supports comments and nested sections", func() {
+				iniBytes := []byte(`; comment
+root = "quoted"
+# another comment
+[database.conn]
+host=localhost
+`)
+				iniMap, err := unmarshal(defaultFileSeparator, iniBytes)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(iniMap.m).To(Equal(map[string]interface{}{
+					"root": "quoted",
+					"database": map[string]interface{}{
+						"conn": map[string]interface{}{"host": "localhost"},
+					},
+				}))
+			})
+		})
 		Context("not supported", func() {
 			It("should fail with random input", func() {
 				randomBytes := []byte("i don't know what I'm doing here")
@@ -84,64 +224,246 @@ test:
 	Describe("setFromString", func() {
 		It("sets anything to zero value if input is empty string", func() {
 			target := &struct{ V testType }{testType{S: "testing"}}
-			Expect(setFromString(wrappedValue(target), "")).To(Succeed())
+			Expect(setFromString(wrappedValue(target), "", parameterConfig{})).To(Succeed())
 			Expect(target.V).To(Equal(testType{}))
 		})
 		It("sets durations correctly", func() {
 			target := &struct{ V time.Duration }{}
-			Expect(setFromString(wrappedValue(target), "2s")).To(Succeed())
+			Expect(setFromString(wrappedValue(target), "2s", parameterConfig{})).To(Succeed())
 			Expect(target.V).To(Equal(2 * time.Second))
 		})
 		It("sets dates correctly", func() {
 			target := &struct{ V time.Time }{}
-			Expect(setFromString(wrappedValue(target), "2007-01-02T15:04:05Z")).To(Succeed())
+			Expect(setFromString(wrappedValue(target), "2007-01-02T15:04:05Z", parameterConfig{})).To(Succeed())
 			Expect(target.V).To(BeEquivalentTo(time.Date(2007, 1, 2, 15, 4, 5, 0, time.UTC)))
 		})
+		It("sets dates from unix timestamps", func() {
+			target := &struct{ V time.Time }{}
+			Expect(setFromString(wrappedValue(target), "1167750245", parameterConfig{TimeFormat: "unix"})).To(Succeed())
+			Expect(target.V.Equal(time.Date(2007, 1, 2, 15, 4, 5, 0, time.UTC))).To(BeTrue())
+
+			Expect(setFromString(wrappedValue(target), "1167750245123", parameterConfig{TimeFormat: "unixmilli"})).To(Succeed())
+			Expect(target.V.Equal(time.Date(2007, 1, 2, 15, 4, 5, 123000000, time.UTC))).To(BeTrue())
+
+			Expect(setFromString(wrappedValue(target), "2007-01-02T15:04:05Z", parameterConfig{TimeFormat: "unix"})).NotTo(Succeed())
+		})
+		It("sets bools from common forms", func() {
+			target := &struct{ V bool }{}
+			for value, expected := range map[string]bool{
+				"yes": true, "On": true, "TRUE": true, "1": true, "t": true,
+				"NO": false, "off": false, "false": false, "0": false, "F": false,
+			} {
+				Expect(setFromString(wrappedValue(target), value, parameterConfig{})).To(Succeed())
+				Expect(target.V).To(Equal(expected), value)
+			}
+
+			Expect(setFromString(wrappedValue(target), "y", parameterConfig{})).NotTo(Succeed())
+		})
 		It("sets int types correctly", func() {
 			target := &struct{ V int }{}
-			Expect(setFromString(wrappedValue(target), "69")).To(Succeed())
+			Expect(setFromString(wrappedValue(target), "69", parameterConfig{})).To(Succeed())
 			Expect(target.V).To(Equal(69))
 		})
+		It("supports prefixed integer literals", func() {
+			target := &struct{ V int }{}
+			for value, expected := range map[string]int{"0xFF": 255, "0o755": 493, "0755": 493, "0b101": 5, "-0x10": -16, "10": 10} {
+				Expect(setFromString(wrappedValue(target), value, parameterConfig{})).To(Succeed())
+				Expect(target.V).To(Equal(expected))
+			}
+
+			uintTarget := &struct{ V uint32 }{}
+			Expect(setFromString(wrappedValue(uintTarget), "0o755", parameterConfig{})).To(Succeed())
+			Expect(uintTarget.V).To(Equal(uint32(493)))
+		})
+		It("respects the bit size of int types", func() {
+			int8Target := &struct{ V int8 }{}
+			Expect(setFromString(wrappedValue(int8Target), "127", parameterConfig{})).To(Succeed())
+			Expect(int8Target.V).To(Equal(int8(math.MaxInt8)))
+			Expect(setFromString(wrappedValue(int8Target), "-128", parameterConfig{})).To(Succeed())
+			Expect(int8Target.V).To(Equal(int8(math.MinInt8)))
+			Expect(setFromString(wrappedValue(int8Target), "128", parameterConfig{})).NotTo(Succeed())
+			Expect(setFromString(wrappedValue(int8Target), "-129", parameterConfig{})).NotTo(Succeed())
+
+			int16Target := &struct{ V int16 }{}
+			Expect(setFromString(wrappedValue(int16Target), "32767", parameterConfig{})).To(Succeed())
+			Expect(int16Target.V).To(Equal(int16(math.MaxInt16)))
+			Expect(setFromString(wrappedValue(int16Target), "32768", parameterConfig{})).NotTo(Succeed())
+			Expect(setFromString(wrappedValue(int16Target), "-32769", parameterConfig{})).NotTo(Succeed())
+
+			int32Target := &struct{ V int32 }{}
+			Expect(setFromString(wrappedValue(int32Target), "-2147483648", parameterConfig{})).To(Succeed())
+			Expect(int32Target.V).To(Equal(int32(math.MinInt32)))
+			Expect(setFromString(wrappedValue(int32Target), "2147483648", parameterConfig{})).NotTo(Succeed())
+
+			int64Target := &struct{ V int64 }{}
+			Expect(setFromString(wrappedValue(int64Target), "9223372036854775807", parameterConfig{})).To(Succeed())
+			Expect(int64Target.V).To(Equal(int64(math.MaxInt64)))
+			Expect(setFromString(wrappedValue(int64Target), "9223372036854775808", parameterConfig{})).NotTo(Succeed())
+		})
+		It("respects the bit size of uint types", func() {
+			uint8Target := &struct{ V uint8 }{}
+			Expect(setFromString(wrappedValue(uint8Target), "255", parameterConfig{})).To(Succeed())
+			Expect(uint8Target.V).To(Equal(uint8(math.MaxUint8)))
+			Expect(setFromString(wrappedValue(uint8Target), "300", parameterConfig{})).NotTo(Succeed())
+			Expect(uint8Target.V).To(Equal(uint8(math.MaxUint8)))
+
+			uint16Target := &struct{ V uint16 }{}
+			Expect(setFromString(wrappedValue(uint16Target), "65535", parameterConfig{})).To(Succeed())
+			Expect(uint16Target.V).To(Equal(uint16(math.MaxUint16)))
+			Expect(setFromString(wrappedValue(uint16Target), "65536", parameterConfig{})).NotTo(Succeed())
+
+			uint32Target := &struct{ V uint32 }{}
+			Expect(setFromString(wrappedValue(uint32Target), "4294967295", parameterConfig{})).To(Succeed())
+			Expect(uint32Target.V).To(Equal(uint32(math.MaxUint32)))
+			Expect(setFromString(wrappedValue(uint32Target), "4294967296", parameterConfig{})).NotTo(Succeed())
+
+			uint64Target := &struct{ V uint64 }{}
+			Expect(setFromString(wrappedValue(uint64Target), "18446744073709551615", parameterConfig{})).To(Succeed())
+			Expect(uint64Target.V).To(Equal(uint64(math.MaxUint64)))
+			Expect(setFromString(wrappedValue(uint64Target), "18446744073709551616", parameterConfig{})).NotTo(Succeed())
+		})
+		It("parses byte sizes if configured", func() {
+			target := &struct{ V int64 }{}
+			config := parameterConfig{ByteSize: true}
+			for value, expected := range map[string]int64{"10MB": 10485760, "10 MiB": 10485760, "1.5kb": 1536, "2G": 2 << 30, "512": 512, "7b": 7} {
+				Expect(setFromString(wrappedValue(target), value, config)).To(Succeed())
+				Expect(target.V).To(Equal(expected))
+			}
+
+			Expect(setFromString(wrappedValue(target), "10XB", config)).To(MatchError(ErrInvalidByteSize))
+			Expect(setFromString(wrappedValue(target), "10MB", parameterConfig{})).NotTo(Succeed())
+
+			uintTarget := &struct{ V uint16 }{}
+			Expect(setFromString(wrappedValue(uintTarget), "63KiB", config)).To(Succeed())
+			Expect(uintTarget.V).To(Equal(uint16(64512)))
+			Expect(setFromString(wrappedValue(uintTarget), "64KiB", config)).To(MatchError(strconv.ErrRange))
+		})
+		It("respects the bit size of float types", func() {
+			target := &struct{ V float32 }{}
+			Expect(setFromString(wrappedValue(target), "3.4e39", parameterConfig{})).NotTo(Succeed())
+		})
 		It("sets booleans correctly", func() {
 			target := &struct{ V bool }{}
-			Expect(setFromString(wrappedValue(target), "true")).To(Succeed())
+			Expect(setFromString(wrappedValue(target), "true", parameterConfig{})).To(Succeed())
 			Expect(target.V).To(Equal(true))
 		})
 		It("sets complex types correctly", func() {
 			target := &struct{ V complex128 }{}
-			Expect(setFromString(wrappedValue(target), "2+3i")).To(Succeed())
+			Expect(setFromString(wrappedValue(target), "2+3i", parameterConfig{})).To(Succeed())
 			Expect(target.V).To(Equal(complex(2, 3)))
 		})
 		It("sets uint types correctly", func() {
 			target := &struct{ V uint }{}
-			Expect(setFromString(wrappedValue(target), "420")).To(Succeed())
+			Expect(setFromString(wrappedValue(target), "420", parameterConfig{})).To(Succeed())
 			Expect(target.V).To(Equal(uint(420)))
 		})
 		It("sets float types correctly", func() {
 			target := &struct{ V float64 }{}
-			Expect(setFromString(wrappedValue(target), "2.34")).To(Succeed())
+			Expect(setFromString(wrappedValue(target), "2.34", parameterConfig{})).To(Succeed())
 			Expect(target.V).To(Equal(2.34))
 		})
 		It("sets strings correctly", func() {
 			target := &struct{ V string }{}
-			Expect(setFromString(wrappedValue(target), "whoop")).To(Succeed())
+			Expect(setFromString(wrappedValue(target), "whoop", parameterConfig{})).To(Succeed())
 			Expect(target.V).To(Equal("whoop"))
 		})
 		It("sets []string correctly", func() {
 			target := &struct{ V []string }{}
-			Expect(setFromString(wrappedValue(target), "wow,insane")).To(Succeed())
+			Expect(setFromString(wrappedValue(target), "wow,insane", parameterConfig{})).To(Succeed())
 			Expect(target.V).To(Equal([]string{"wow", "insane"}))
 		})
 		It("sets map[string]string correctly", func() {
 			target := &struct{ V map[string]string }{}
-			Expect(setFromString(wrappedValue(target), "wow=insane")).To(Succeed())
+			Expect(setFromString(wrappedValue(target), "wow=insane", parameterConfig{})).To(Succeed())
 			Expect(target.V).To(Equal(map[string]string{"wow": "insane"}))
 		})
+		It("supports quoted elements for []string", func() {
+			target := &struct{ V []string }{}
+			Expect(setFromString(wrappedValue(target), `"a,b", c ,"say ""hi"" " ,d`, parameterConfig{})).To(Succeed())
+			Expect(target.V).To(Equal([]string{"a,b", "c", `say "hi" `, "d"}))
+		})
+		It("returns error for malformed quotes in []string", func() {
+			target := &struct{ V []string }{}
+			Expect(setFromString(wrappedValue(target), `"a,b`, parameterConfig{})).To(MatchError(ErrMalformedList))
+			Expect(setFromString(wrappedValue(target), `"a"b,c`, parameterConfig{})).To(MatchError(ErrMalformedList))
+		})
+		It("uses configured separators for []string", func() {
+			target := &struct{ V []string }{}
+			config := parameterConfig{ListSeparator: ";"}
+			Expect(setFromString(wrappedValue(target), "a,b; c", config)).To(Succeed())
+			Expect(target.V).To(Equal([]string{"a,b", "c"}))
+		})
+		It("supports quoted values containing the default separator with a configured separator", func() {
+			target := &struct{ V []string }{}
+			Expect(setFromString(wrappedValue(target), `"a;b";c,d`, parameterConfig{ListSeparator: ";"})).To(Succeed())
+			Expect(target.V).To(Equal([]string{"a;b", "c,d"}))
+		})
+		It("supports duration slices and reports the index of invalid elements", func() {
+			target := &struct{ V []time.Duration }{}
+			Expect(setFromString(wrappedValue(target), "1s,2s,5s", parameterConfig{})).To(Succeed())
+			Expect(target.V).To(Equal([]time.Duration{time.Second, 2 * time.Second, 5 * time.Second}))
+
+			err := setFromString(wrappedValue(target), "1s,2x,5s", parameterConfig{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix(`element 1 of "1s,2x,5s"`))
+		})
+		It("supports maps with other key and value types", func() {
+			intKeys := &struct{ V map[int]string }{}
+			Expect(setFromString(wrappedValue(intKeys), "1=a,2=b", parameterConfig{})).To(Succeed())
+			Expect(intKeys.V).To(Equal(map[int]string{1: "a", 2: "b"}))
+
+			intValues := &struct{ V map[string]int }{}
+			Expect(setFromString(wrappedValue(intValues), "a=1,b=2", parameterConfig{})).To(Succeed())
+			Expect(intValues.V).To(Equal(map[string]int{"a": 1, "b": 2}))
+
+			Expect(setFromString(wrappedValue(intKeys), "a=1", parameterConfig{})).NotTo(Succeed())
+		})
+		It("uses configured separators for map[string]string", func() {
+			target := &struct{ V map[string]string }{}
+			config := parameterConfig{ListSeparator: ";", KeyValueSeparator: ":"}
+			Expect(setFromString(wrappedValue(target), "a:x,y=z; b:c", config)).To(Succeed())
+			Expect(target.V).To(Equal(map[string]string{"a": "x,y=z", "b": "c"}))
+		})
+		It("returns error for map entries without value", func() {
+			target := &struct{ V map[string]string }{}
+			Expect(setFromString(wrappedValue(target), "a=b,c", parameterConfig{})).To(MatchError(ErrMalformedMapEntry))
+		})
+		It("sets slices of other types correctly", func() {
+			target := &struct{ V []int }{}
+			Expect(setFromString(wrappedValue(target), "1, 0x2,3", parameterConfig{})).To(Succeed())
+			Expect(target.V).To(Equal([]int{1, 2, 3}))
+			Expect(setFromString(wrappedValue(target), "1,a", parameterConfig{})).NotTo(Succeed())
+		})
+		It("sets slices of TextUnmarshaler correctly", func() {
+			target := &struct{ V []testType }{}
+			Expect(setFromString(wrappedValue(target), `a;"b;c"`, parameterConfig{ListSeparator: ";"})).To(Succeed())
+			Expect(target.V).To(Equal([]testType{{S: "a"}, {S: "b;c"}}))
+		})
 		It("sets TextUnmarshaler correctly", func() {
 			target := &struct{ V testType }{}
-			Expect(setFromString(wrappedValue(target), "mmh")).To(Succeed())
+			Expect(setFromString(wrappedValue(target), "mmh", parameterConfig{})).To(Succeed())
 			Expect(target.V).To(Equal(testType{S: "mmh"}))
 		})
+		It("sets []byte from base64 correctly", func() {
+			target := &struct{ V []byte }{}
+			Expect(setFromString(wrappedValue(target), "aGk/Pz4+", parameterConfig{})).To(Succeed())
+			Expect(target.V).To(Equal([]byte("hi??>>")))
+		})
+		It("sets []byte from base64 with configured encoding", func() {
+			target := &struct{ V []byte }{}
+			config := parameterConfig{Base64Encoding: base64.RawURLEncoding}
+			Expect(setFromString(wrappedValue(target), "aGk_Pz4-", config)).To(Succeed())
+			Expect(target.V).To(Equal([]byte("hi??>>")))
+		})
+		It("returns error on invalid base64", func() {
+			target := &struct{ V []byte }{}
+			Expect(setFromString(wrappedValue(target), "not base64!", parameterConfig{})).NotTo(Succeed())
+		})
+		It("sets BinaryUnmarshaler correctly", func() {
+			target := &struct{ V testBinaryType }{}
+			Expect(setFromString(wrappedValue(target), "mmh", parameterConfig{})).To(Succeed())
+			Expect(target.V).To(Equal(testBinaryType{B: []byte("mmh")}))
+		})
 	})
 	Context("field function", func() {
 		type targetType struct {
@@ -183,62 +505,259 @@ test:
 		})
 
 		Describe("readPFlags", func() {
-			config := FlagsConfig{
-				Separator: "-",
-				Disabled:  false,
+			c := &Collector{
+				Flags: FlagsConfig{
+					Separator: "-",
+					Disabled:  false,
+				},
 			}
 
 			It("uses name as default flag name", func() {
-				err := readPFlags(fields, config, []string{"--port", "3000"})
+				err := c.readPFlags(fields, []string{"--port", "3000"})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(target.V).To(Equal(3000))
 			})
 			It("uses configured long name", func() {
 				fields[0].Config.Flag.DefaultName = "overwrite"
-				err := readPFlags(fields, config, []string{"--overwrite", "3000"})
+				err := c.readPFlags(fields, []string{"--overwrite", "3000"})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(target.V).To(Equal(3000))
 			})
+			It("only registers the flags from the tags if configured", func() {
+				onlyTagged := &Collector{
+					Files: FilesConfig{Disabled: true},
+					Env:   EnvConfig{Disabled: true},
+					Flags: FlagsConfig{Separator: "-", OnlyTagged: true},
+				}
+				cfg := struct {
+					Port    int  `config:"flag=p listen"`
+					Verbose bool `config:"flag=v"`
+					Debug   bool
+				}{}
+
+				onlyTagged.Flags.Args = []string{"-p", "1", "-v", "--debug", "--port", "2"}
+				Expect(onlyTagged.Get(&cfg)).To(Succeed())
+				Expect(cfg.Port).To(Equal(1))
+				Expect(cfg.Verbose).To(BeTrue())
+				Expect(cfg.Debug).To(BeFalse())
+
+				onlyTagged.Flags.Args = []string{"--listen", "3", "--no-verbose"}
+				Expect(onlyTagged.Get(&cfg)).To(Succeed())
+				Expect(cfg.Port).To(Equal(3))
+				Expect(cfg.Verbose).To(BeFalse())
+			})
+			It("doesn't panic for nil pointers to non-struct types", func() {
+				nilPointer := &Collector{
+					Files: FilesConfig{Disabled: true},
+					Env:   EnvConfig{Disabled: true},
+					Flags: FlagsConfig{Separator: "-"},
+				}
+				cfg := struct {
+					P  *int
+					PS *[]string
+				}{}
+
+				Expect(nilPointer.Get(&cfg, WithArgs())).To(Succeed())
+				Expect(cfg.P).To(BeNil())
+				Expect(nilPointer.Get(&cfg, WithArgs("--p", "1"))).To(MatchError(ErrCantSet))
+				Expect(nilPointer.Get(&cfg, WithArgs("--ps", "a"))).To(MatchError(ErrCantSet))
+			})
+			It("supports dots as separator independent of the other sources", func() {
+				dotted := &Collector{
+					Files: FilesConfig{Disabled: true},
+					Env:   EnvConfig{Separator: "_"},
+					Flags: FlagsConfig{Prefix: "app", Separator: "."},
+				}
+				cfg := struct {
+					Server struct {
+						Port int
+						TLS  bool
+					}
+				}{}
+
+				Expect(dotted.Get(&cfg, WithArgs("--app.server.port", "1", "--app.server.tls"))).To(Succeed())
+				Expect(cfg.Server.Port).To(Equal(1))
+				Expect(cfg.Server.TLS).To(BeTrue())
+
+				dotted.Flags.UseStdFlag = true
+				Expect(dotted.Get(&cfg, WithArgs("-app.server.port=2"))).To(Succeed())
+				Expect(cfg.Server.Port).To(Equal(2))
+			})
+			It("uses prefix for the generated name only", func() {
+				c := &Collector{Flags: FlagsConfig{Prefix: "myapp", Separator: "-"}}
+				fields[0].Config.Flag.ShortName = "o"
+
+				Expect(c.readPFlags(fields, []string{"--myapp-port", "3000"})).To(Succeed())
+				Expect(target.V).To(Equal(3000))
+				Expect(c.readPFlags(fields, []string{"-o", "3001"})).To(Succeed())
+				Expect(target.V).To(Equal(3001))
+				Expect(c.readPFlags(fields, []string{"--port", "3002"})).To(Succeed())
+				Expect(target.V).To(Equal(3001))
+			})
 			It("uses configured short name", func() {
 				fields[0].Config.Flag.ShortName = "o"
-				err := readPFlags(fields, config, []string{"-o", "3000"})
+				err := c.readPFlags(fields, []string{"-o", "3000"})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(target.V).To(Equal(3000))
 			})
+			It("registers bool fields as bool flags", func() {
+				boolTarget := &struct{ V bool }{}
+				boolFields := []*field{{Name: "verbose", Value: wrappedValue(boolTarget)}}
+
+				Expect(c.readPFlags(boolFields, []string{"--verbose"})).To(Succeed())
+				Expect(boolTarget.V).To(BeTrue())
+
+				Expect(c.readPFlags(boolFields, []string{"--verbose=false"})).To(Succeed())
+				Expect(boolTarget.V).To(BeFalse())
+			})
+			It("sets bool fields to false with negated flags", func() {
+				boolTarget := &struct{ V bool }{V: true}
+				boolFields := []*field{{Name: "verbose", Value: wrappedValue(boolTarget)}}
+
+				Expect(c.readPFlags(boolFields, []string{"--no-verbose"})).To(Succeed())
+				Expect(boolTarget.V).To(BeFalse())
+
+				Expect(c.readPFlags(boolFields, []string{"--no-verbose", "--verbose"})).To(Succeed())
+				Expect(boolTarget.V).To(BeTrue())
+
+				Expect(c.readPFlags(boolFields, []string{"--verbose", "--no-verbose"})).To(Succeed())
+				Expect(boolTarget.V).To(BeFalse())
+
+				Expect(c.readPFlags(boolFields, []string{"--no-verbose=false"})).To(Succeed())
+				Expect(boolTarget.V).To(BeTrue())
+			})
+			It("sets empty slices and maps instead of nil for empty values", func() {
+				emptyTarget := &struct {
+					Tags   []string
+					Labels map[string]string
+				}{Tags: []string{"a"}, Labels: map[string]string{"a": "b"}}
+				emptyFields := []*field{
+					{Name: "tags", Value: reflect.ValueOf(emptyTarget).Elem().Field(0)},
+					{Name: "labels", Value: reflect.ValueOf(emptyTarget).Elem().Field(1)},
+				}
+
+				Expect(c.readPFlags(emptyFields, []string{"--tags=", "--labels", ""})).To(Succeed())
+				Expect(emptyTarget.Tags).NotTo(BeNil())
+				Expect(emptyTarget.Tags).To(BeEmpty())
+				Expect(emptyTarget.Labels).NotTo(BeNil())
+				Expect(emptyTarget.Labels).To(BeEmpty())
+			})
+			It("counts flags of int fields if configured", func() {
+				countTarget := &struct{ V int }{}
+				countFields := []*field{{
+					Name:   "verbose",
+					Value:  wrappedValue(countTarget),
+					Config: parameterConfig{Flag: flag{ShortName: "v"}, Count: true},
+				}}
+
+				Expect(c.readPFlags(countFields, []string{"-vvv"})).To(Succeed())
+				Expect(countTarget.V).To(Equal(3))
+
+				Expect(c.readPFlags(countFields, []string{"-v", "--verbose"})).To(Succeed())
+				Expect(countTarget.V).To(Equal(2))
+
+				Expect(c.readPFlags(countFields, []string{"--verbose=5"})).To(Succeed())
+				Expect(countTarget.V).To(Equal(5))
+			})
+			It("appends repeated flags to slice fields", func() {
+				sliceTarget := &struct{ V []int }{}
+				sliceFields := []*field{{Name: "ports", Value: wrappedValue(sliceTarget)}}
+
+				Expect(c.readPFlags(sliceFields, []string{"--ports", "1", "--ports", "2,3"})).To(Succeed())
+				Expect(sliceTarget.V).To(Equal([]int{1, 2, 3}))
+
+				Expect(c.readPFlags(sliceFields, []string{"--ports", "4"})).To(Succeed())
+				Expect(sliceTarget.V).To(Equal([]int{4}))
+			})
+			It("doesn't repeat byte slice flags", func() {
+				bytesTarget := &struct{ V []byte }{}
+				bytesFields := []*field{{Name: "key", Value: wrappedValue(bytesTarget)}}
+
+				Expect(c.readPFlags(bytesFields, []string{"--key", "YQ==", "--key", "Yg=="})).To(Succeed())
+				Expect(bytesTarget.V).To(Equal([]byte("b")))
+			})
+			It("registers flags with the default name only if defined in the struct tag", func() {
+				tagTarget := &struct{ A, B, C int }{}
+				tagFields := []*field{
+					{Name: "a", Value: wrappedValue(tagTarget)},
+					{Name: "b", Value: wrappedValue(tagTarget), Config: parameterConfig{Flag: flag{DefaultName: "shared"}}},
+					{Name: "c", Value: wrappedValue(tagTarget), Config: parameterConfig{Flag: flag{DefaultName: "shared"}}},
+				}
+
+				var registered []string
+				fieldToFlagNames := c.mapFlags(tagFields, func(_ *field, name, _, _ string) {
+					registered = append(registered, name)
+				})
+
+				Expect(registered).To(Equal([]string{"a", "shared", "b", "c"}))
+				Expect(fieldToFlagNames).To(Equal([][]string{{"a"}, {"shared", "b"}, {"shared", "c"}}))
+			})
+			It("doesn't register flags with empty names for fields without flag tag", func() {
+				noTagTarget := struct {
+					Port int
+					API  struct{ Host string }
+				}{}
+				noTagFields, err := getFieldsConfigsFromValue(reflect.ValueOf(&noTagTarget).Elem())
+				Expect(err).ShouldNot(HaveOccurred())
+
+				c.mapFlags(noTagFields, func(_ *field, name, _, _ string) {
+					Expect(name).NotTo(BeEmpty())
+				})
+
+				Expect(c.readPFlags(noTagFields, []string{"--port", "1", "--api-host", "localhost"})).To(Succeed())
+				Expect(noTagTarget.Port).To(Equal(1))
+				Expect(noTagTarget.API.Host).To(Equal("localhost"))
+			})
+			It("ignores args after the flag terminator", func() {
+				Expect(c.readPFlags(fields, []string{"--port", "8080", "--", "--port", "9090"})).To(Succeed())
+				Expect(target.V).To(Equal(8080))
+			})
+			It("ignores args after the flag terminator following unknown flags", func() {
+				Expect(c.readPFlags(fields, []string{"--unknown", "--port", "8080", "--", "--port", "9090"})).To(Succeed())
+				Expect(target.V).To(Equal(8080))
+			})
 			It("doesn't overwrite with empty value if not set", func() {
 				target.V = 3000
-				err := readPFlags(fields, config, []string{})
+				err := c.readPFlags(fields, []string{})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(target.V).To(Equal(3000))
 			})
 			It("overwrites with empty value if set to empty", func() {
 				target.V = 3000
-				err := readPFlags(fields, config, []string{"--port", ""})
+				err := c.readPFlags(fields, []string{"--port", ""})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(target.V).To(Equal(0))
 			})
 			Context("nested", func() {
 				It("uses separator", func() {
-					err := readPFlags(nestedFields, config, []string{"--sub-port", "1234"})
+					err := c.readPFlags(nestedFields, []string{"--sub-port", "1234"})
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(nestedTarget.Sub.V).To(Equal(1234))
 				})
 				It("can use defaults", func() {
 					nestedFields[0].Config.Flag.DefaultName = "default"
-					err := readPFlags(nestedFields, config, []string{"--default", "1234"})
+					err := c.readPFlags(nestedFields, []string{"--default", "1234"})
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(nestedTarget.Sub.V).To(Equal(1234))
 				})
 				It("uses distinct name instead of overridden/default if both are set", func() {
 					nestedFields[0].Config.Flag.DefaultName = "default"
-					err := readPFlags(nestedFields, config, []string{"--default", "1234", "--sub-port", "1235"})
+					err := c.readPFlags(nestedFields, []string{"--default", "1234", "--sub-port", "1235"})
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(nestedTarget.Sub.V).To(Equal(1235))
 				})
+				It("applies the distinct name after the default name regardless of the order of the args", func() {
+					nestedFields[0].Config.Flag.DefaultName = "default"
+					for i := 0; i < 10; i++ {
+						err := c.readPFlags(nestedFields, []string{"--sub-port", "1235", "--default", "1234"})
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(nestedTarget.Sub.V).To(Equal(1235))
+					}
+				})
 				It("works if multiple fields are trying to get the same default flag", func() {
 					nestedFields[0].Config.Flag.DefaultName = "default"
 					nestedFields[1].Config.Flag.DefaultName = "default"
-					err := readPFlags(nestedFields, config, []string{"--default", "1234", "--sub-port", "1235"})
+					err := c.readPFlags(nestedFields, []string{"--default", "1234", "--sub-port", "1235"})
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(nestedTarget.Sub.V).To(Equal(1235))
 					Expect(nestedTarget.Sub.W).To(Equal(1234))
@@ -247,72 +766,204 @@ test:
 		})
 
 		Describe("readEnv", func() {
-			var config EnvConfig
+			var c *Collector
 			BeforeEach(func() {
-				config = EnvConfig{
-					Prefix:    "",
-					Separator: "_",
-					Disabled:  false,
+				c = &Collector{
+					Env: EnvConfig{
+						Prefix:    "",
+						Separator: "_",
+						Disabled:  false,
+					},
 				}
 			})
 			It("uses uppercase name as default env name", func() {
-				err := readEnv(fields, config, map[string]string{"PORT": "3000"})
+				err := c.readEnv(fields, map[string]string{"PORT": "3000"})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(target.V).To(Equal(3000))
+			})
+			It("sets bools with the presence key to true if the env var is set", func() {
+				presenceTarget := struct {
+					Debug bool `config:"env=DEBUG,presence"`
+				}{}
+				presenceFields, err := getFieldsConfigsFromValue(reflect.ValueOf(&presenceTarget).Elem())
 				Expect(err).ShouldNot(HaveOccurred())
+
+				for value, expected := range map[string]bool{"": true, "1": true, "anything": true, "0": false, "no": false} {
+					presenceTarget.Debug = !expected
+					Expect(c.readEnv(presenceFields, map[string]string{"DEBUG": value})).To(Succeed())
+					Expect(presenceTarget.Debug).To(Equal(expected), value)
+				}
+
+				presenceTarget.Debug = false
+				Expect(c.readEnv(presenceFields, map[string]string{})).To(Succeed())
+				Expect(presenceTarget.Debug).To(BeFalse())
+
+				invalidTarget := struct {
+					Port int `config:"presence"`
+				}{}
+				invalidFields, err := getFieldsConfigsFromValue(reflect.ValueOf(&invalidTarget).Elem())
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(c.readEnv(invalidFields, map[string]string{"PORT": "1"})).To(MatchError(ErrUnsupportedType))
+			})
+			It("trims whitespace from the values if configured", func() {
+				Expect(c.readEnv(fields, map[string]string{"PORT": " 3000\n"})).ToNot(Succeed())
+
+				c.Env.TrimSpace = true
+				Expect(c.readEnv(fields, map[string]string{"PORT": " 3000\n"})).To(Succeed())
 				Expect(target.V).To(Equal(3000))
 			})
 			It("uses configured name", func() {
 				fields[0].Config.DefaultEnvName = "overwrite"
-				err := readEnv(fields, config, map[string]string{"OVERWRITE": "3000"})
+				err := c.readEnv(fields, map[string]string{"OVERWRITE": "3000"})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(target.V).To(Equal(3000))
 			})
 			It("uses prefix", func() {
-				config.Prefix = "prefix"
-				err := readEnv(fields, config, map[string]string{"PREFIX_PORT": "3000"})
+				c.Env.Prefix = "prefix"
+				err := c.readEnv(fields, map[string]string{"PREFIX_PORT": "3000"})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(target.V).To(Equal(3000))
 			})
+			It("prefixes configured names if configured", func() {
+				c.Env.Prefix = "prefix"
+				c.Env.PrefixExplicitNames = true
+				fields[0].Config.DefaultEnvName = "overwrite"
+				err := c.readEnv(fields, map[string]string{"OVERWRITE": "3000", "PREFIX_OVERWRITE": "3001"})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(target.V).To(Equal(3001))
+			})
+			It("reads values from files with the file suffix", func() {
+				dir, err := os.MkdirTemp("", "tests*")
+				Expect(err).ShouldNot(HaveOccurred())
+				defer os.RemoveAll(dir)
+				secretFile := path.Join(dir, "secret")
+				Expect(os.WriteFile(secretFile, []byte("3000\n"), 0600)).To(Succeed())
+
+				c.Env.FileSuffix = "_FILE"
+				Expect(c.readEnv(fields, map[string]string{"PORT_FILE": secretFile})).To(Succeed())
+				Expect(target.V).To(Equal(3000))
+
+				Expect(c.readEnv(fields, map[string]string{"PORT_FILE": secretFile, "PORT": "3001"})).To(Succeed())
+				Expect(target.V).To(Equal(3001))
+			})
+			It("doesn't use file variables for structs and names of other fields", func() {
+				dir, err := os.MkdirTemp("", "tests*")
+				Expect(err).ShouldNot(HaveOccurred())
+				defer os.RemoveAll(dir)
+				certFile := path.Join(dir, "cert")
+				Expect(os.WriteFile(certFile, []byte("content"), 0600)).To(Succeed())
+
+				fileTarget := struct {
+					Log      struct{ File string }
+					Cert     string
+					CertFile string
+				}{}
+				fileFields, err := getFieldsConfigsFromValue(reflect.ValueOf(&fileTarget).Elem())
+				Expect(err).ShouldNot(HaveOccurred())
+
+				c.Env.FileSuffix = "_FILE"
+				c.Env.SnakeCase = true
+				Expect(c.readEnv(fileFields, map[string]string{
+					"LOG_FILE":  "/var/log/not-existing.log",
+					"CERT_FILE": certFile,
+				})).To(Succeed())
+				Expect(fileTarget.Log.File).To(Equal("/var/log/not-existing.log"))
+				Expect(fileTarget.Cert).To(BeEmpty())
+				Expect(fileTarget.CertFile).To(Equal(certFile))
+			})
+			It("doesn't read files if no file suffix is configured", func() {
+				Expect(c.readEnv(fields, map[string]string{"PORT_FILE": "/not/existing"})).To(Succeed())
+				Expect(target.V).To(Equal(0))
+			})
+			It("returns error if the file can't be read", func() {
+				c.Env.FileSuffix = "_FILE"
+				Expect(c.readEnv(fields, map[string]string{"PORT_FILE": "/not/existing"})).NotTo(Succeed())
+			})
+			It("decodes JSON values if configured", func() {
+				jsonTarget := struct {
+					Features struct{ A, B, C bool } `config:"json"`
+					Limits   map[string]int         `config:"json"`
+				}{}
+				jsonTarget.Features.C = true
+				jsonFields, err := getFieldsConfigsFromValue(reflect.ValueOf(&jsonTarget).Elem())
+				Expect(err).ShouldNot(HaveOccurred())
+
+				Expect(c.readEnv(jsonFields, map[string]string{
+					"FEATURES":   `{"a":true,"b":false}`,
+					"FEATURES_B": "true",
+					"LIMITS":     `{"cpu":2}`,
+				})).To(Succeed())
+				Expect(jsonTarget.Features).To(Equal(struct{ A, B, C bool }{A: true, B: true, C: true}))
+				Expect(jsonTarget.Limits).To(Equal(map[string]int{"cpu": 2}))
+
+				Expect(c.readEnv(jsonFields, map[string]string{"LIMITS": "cpu=2"})).NotTo(Succeed())
+			})
+			It("never prefixes names with the absolute marker", func() {
+				c.Env.Prefix = "prefix"
+				c.Env.PrefixExplicitNames = true
+				fields[0].Config.DefaultEnvName = "!home"
+				err := c.readEnv(fields, map[string]string{"HOME": "3000", "PREFIX_HOME": "3001"})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(target.V).To(Equal(3000))
+			})
+			It("converts field names to snake case if configured", func() {
+				c.Env.Prefix = "myapp"
+				c.Env.SnakeCase = true
+				snakeTarget := struct {
+					MaxConnections int
+					DB             struct{ APIKey string }
+				}{}
+				snakeFields, err := getFieldsConfigsFromValue(reflect.ValueOf(&snakeTarget).Elem())
+				Expect(err).ShouldNot(HaveOccurred())
+
+				Expect(c.readEnv(snakeFields, map[string]string{
+					"MYAPP_MAX_CONNECTIONS": "10",
+					"MYAPP_DB_API_KEY":      "secret",
+				})).To(Succeed())
+				Expect(snakeTarget.MaxConnections).To(Equal(10))
+				Expect(snakeTarget.DB.APIKey).To(Equal("secret"))
+			})
 			It("doesn't use prefix if name is configured", func() {
-				config.Prefix = "prefix"
+				c.Env.Prefix = "prefix"
 				fields[0].Config.DefaultEnvName = "overwrite"
-				err := readEnv(fields, config, map[string]string{"OVERWRITE": "3000"})
+				err := c.readEnv(fields, map[string]string{"OVERWRITE": "3000"})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(target.V).To(Equal(3000))
 			})
 			It("doesn't overwrite with empty value if not set", func() {
 				target.V = 3000
-				err := readEnv(fields, config, map[string]string{})
+				err := c.readEnv(fields, map[string]string{})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(target.V).To(Equal(3000))
 			})
 			It("overwrites with empty value if set to empty", func() {
 				target.V = 3000
-				err := readEnv(fields, config, map[string]string{"PORT": ""})
+				err := c.readEnv(fields, map[string]string{"PORT": ""})
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(target.V).To(Equal(0))
 			})
 			Context("nested", func() {
 				It("uses separator", func() {
-					err := readEnv(nestedFields, config, map[string]string{"SUB_PORT": "1234"})
+					err := c.readEnv(nestedFields, map[string]string{"SUB_PORT": "1234"})
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(nestedTarget.Sub.V).To(Equal(1234))
 				})
 				It("can be overridden", func() {
 					nestedFields[0].Config.DefaultEnvName = "PORT"
-					err := readEnv(nestedFields, config, map[string]string{"PORT": "1234"})
+					err := c.readEnv(nestedFields, map[string]string{"PORT": "1234"})
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(nestedTarget.Sub.V).To(Equal(1234))
 				})
 				It("uses distinct name instead of overridden/default if both are set", func() {
 					nestedFields[0].Config.DefaultEnvName = "DEFAULT"
-					err := readEnv(nestedFields, config, map[string]string{"DEFAULT": "1234", "SUB_PORT": "1235"})
+					err := c.readEnv(nestedFields, map[string]string{"DEFAULT": "1234", "SUB_PORT": "1235"})
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(nestedTarget.Sub.V).To(Equal(1235))
 				})
 				It("works if multiple fields are trying to get the same default flag", func() {
 					nestedFields[0].Config.DefaultEnvName = "DEFAULT"
 					nestedFields[1].Config.DefaultEnvName = "DEFAULT"
-					err := readEnv(nestedFields, config, map[string]string{"DEFAULT": "1234", "SUB_PORT": "1235"})
+					err := c.readEnv(nestedFields, map[string]string{"DEFAULT": "1234", "SUB_PORT": "1235"})
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(nestedTarget.Sub.V).To(Equal(1235))
 					Expect(nestedTarget.Sub.W).To(Equal(1234))
@@ -324,115 +975,508 @@ test:
 			separator := "."
 
 			Describe("readFiles", func() {
-				var config FilesConfig
+				var c *Collector
 				var baseFileName string
 				var dir string
 				BeforeEach(func() {
 					var err error
-					dir, err = ioutil.TempDir("", "tests*")
+					dir, err = os.MkdirTemp("", "tests*")
 					Expect(err).ShouldNot(HaveOccurred())
 
 					baseFileName = "testing"
-					config = FilesConfig{
-						Locations: []string{dir},
-						BaseName:  baseFileName,
-						Separator: separator,
-						Disabled:  false,
+					c = &Collector{
+						Files: FilesConfig{
+							Locations: []string{dir},
+							BaseName:  baseFileName,
+							Separator: separator,
+							Disabled:  false,
+						},
 					}
 				})
 				AfterEach(func() {
 					Expect(os.RemoveAll(dir)).To(Succeed())
 				})
 				It("returns an error if no config file is found", func() {
-					err := readFiles(fields, config)
+					err := c.readFiles(fields)
 					Expect(err).Should(HaveOccurred())
 					Expect(err).To(Equal(ErrNoFileFound))
 				})
+				It("returns ErrNoFileFound from Get only if files are required", func() {
+					cfg := struct{ Port int }{}
+					Expect(c.Get(&cfg, WithoutEnv(), WithoutFlags())).To(Succeed())
+
+					c.Files.Required = true
+					Expect(c.Get(&cfg, WithoutEnv(), WithoutFlags())).To(MatchError(ErrNoFileFound))
+
+					Expect(os.WriteFile(path.Join(dir, baseFileName+".yaml"), []byte(`port: 1`), 0600)).To(Succeed())
+					Expect(c.Get(&cfg, WithoutEnv(), WithoutFlags())).To(Succeed())
+					Expect(cfg.Port).To(Equal(1))
+				})
 				It("supports yaml, uses name as default file field, ignores extension", func() {
 					yamlBytes := []byte(`port: 3000`)
-					Expect(ioutil.WriteFile(path.Join(dir, baseFileName+".yaml"), yamlBytes, 0600)).To(Succeed())
+					Expect(os.WriteFile(path.Join(dir, baseFileName+".yaml"), yamlBytes, 0600)).To(Succeed())
 
-					Expect(readFiles(fields, config)).To(Succeed())
+					Expect(c.readFiles(fields)).To(Succeed())
 					Expect(target.V).To(Equal(3000))
 				})
 				It("supports json, uses name as default file field, ignores extension", func() {
 					jsonBytes := []byte(`{"port":3000}`)
-					Expect(ioutil.WriteFile(path.Join(dir, baseFileName), jsonBytes, 0600)).To(Succeed())
+					Expect(os.WriteFile(path.Join(dir, baseFileName), jsonBytes, 0600)).To(Succeed())
+
+					Expect(c.readFiles(fields)).To(Succeed())
+					Expect(target.V).To(Equal(3000))
+				})
+				It("supports yaml merge keys for nested fields", func() {
+					yamlBytes := []byte("base: &base\n  port: 1234\nsub:\n  <<: *base\n")
+					Expect(os.WriteFile(path.Join(dir, baseFileName+".yaml"), yamlBytes, 0600)).To(Succeed())
+
+					Expect(c.readFiles(nestedFields)).To(Succeed())
+					Expect(nestedTarget.Sub.V).To(Equal(1234))
+				})
+				It("supports ini, maps sections to nested fields", func() {
+					iniBytes := []byte("[sub]\nport = 1234\n")
+					Expect(os.WriteFile(path.Join(dir, baseFileName+".ini"), iniBytes, 0600)).To(Succeed())
+
+					Expect(c.readFiles(nestedFields)).To(Succeed())
+					Expect(nestedTarget.Sub.V).To(Equal(1234))
+				})
+				It("returns the error of malformed ini files", func() {
+					iniBytes := []byte("[sub]\nport = 1234\n[sub\n")
+					Expect(os.WriteFile(path.Join(dir, baseFileName+".ini"), iniBytes, 0600)).To(Succeed())
+
+					Expect(c.readFiles(nestedFields)).To(MatchError("malformed ini: invalid section header in line 3"))
+				})
+				Context("multiple locations", func() {
+					var otherDir string
+					BeforeEach(func() {
+						var err error
+						otherDir, err = os.MkdirTemp("", "tests*")
+						Expect(err).ShouldNot(HaveOccurred())
+
+						c.Files.Locations = append(c.Files.Locations, otherDir)
+						Expect(os.WriteFile(path.Join(dir, baseFileName), []byte(`{"port":1}`), 0600)).To(Succeed())
+						Expect(os.WriteFile(path.Join(otherDir, baseFileName), []byte(`{"port":2}`), 0600)).To(Succeed())
+					})
+					AfterEach(func() {
+						Expect(os.RemoveAll(otherDir)).To(Succeed())
+					})
+					It("lets later locations win by default", func() {
+						Expect(c.readFiles(fields)).To(Succeed())
+						Expect(target.V).To(Equal(2))
+					})
+					It("lets earlier locations win if configured", func() {
+						c.Files.Order = FirstWins
+						Expect(c.readFiles(fields)).To(Succeed())
+						Expect(target.V).To(Equal(1))
+					})
+				})
+				It("only loads the file from the path env var if it's set", func() {
+					c.Files.PathEnvVar = "PATHENVTEST_CONFIG"
+					explicitFile := path.Join(dir, "explicit.yaml")
+					Expect(os.WriteFile(explicitFile, []byte(`port: 1`), 0600)).To(Succeed())
+					Expect(os.WriteFile(path.Join(dir, baseFileName+".yaml"), []byte(`port: 2`), 0600)).To(Succeed())
+
+					Expect(c.readFiles(fields)).To(Succeed())
+					Expect(target.V).To(Equal(2))
+
+					Expect(os.Setenv("PATHENVTEST_CONFIG", explicitFile)).To(Succeed())
+					defer os.Unsetenv("PATHENVTEST_CONFIG")
+
+					c.loadedFiles = nil
+					Expect(c.readFiles(fields)).To(Succeed())
+					Expect(target.V).To(Equal(1))
+					Expect(c.loadedFiles).To(HaveLen(1))
+
+					Expect(os.Setenv("PATHENVTEST_CONFIG", path.Join(dir, "missing.yaml"))).To(Succeed())
+					Expect(c.readFiles(fields)).To(MatchError(os.ErrNotExist))
+				})
+				It("applies files with the same base name by the extension priority", func() {
+					Expect(os.WriteFile(path.Join(dir, baseFileName+".json"), []byte(`{"port":1}`), 0600)).To(Succeed())
+					Expect(os.WriteFile(path.Join(dir, baseFileName+".yaml"), []byte(`port: 2`), 0600)).To(Succeed())
+					Expect(os.WriteFile(path.Join(dir, baseFileName+".yml"), []byte(`port: 3`), 0600)).To(Succeed())
 
-					Expect(readFiles(fields, config)).To(Succeed())
+					Expect(c.readFiles(fields)).To(Succeed())
+					Expect(target.V).To(Equal(3))
+
+					c.Files.ExtensionPriority = []string{".JSON", "yaml"}
+					Expect(c.readFiles(fields)).To(Succeed())
+					Expect(target.V).To(Equal(1))
+
+					c.Files.Order = FirstWins
+					Expect(c.readFiles(fields)).To(Succeed())
+					Expect(target.V).To(Equal(1))
+
+					c.Files.ExtensionPriority = []string{"yaml", "json"}
+					Expect(c.readFiles(fields)).To(Succeed())
+					Expect(target.V).To(Equal(2))
+				})
+				It("parses native and quoted yaml timestamps the same way", func() {
+					timeTarget := struct {
+						Native time.Time
+						Quoted time.Time
+						Date   time.Time
+						Custom time.Time   `config:"timeformat=2006-01-02"`
+						List   []time.Time `config:"timeformat=2006-01-02"`
+						Name   string
+					}{}
+					timeFields, err := getFieldsConfigsFromValue(reflect.ValueOf(&timeTarget).Elem())
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(os.WriteFile(path.Join(dir, baseFileName+".yaml"), []byte(
+						"native: 2021-01-01T00:00:00Z\nquoted: \"2021-01-01T00:00:00Z\"\ndate: 2021-01-01\n"+
+							"custom: \"2021-01-01\"\nlist: [2021-01-01, \"2021-01-02\"]\nname: 2021-01-01\n",
+					), 0600)).To(Succeed())
+
+					Expect(c.readFiles(timeFields)).To(Succeed())
+					expected := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+					Expect(timeTarget.Native).To(Equal(expected))
+					Expect(timeTarget.Quoted).To(Equal(expected))
+					Expect(timeTarget.Date).To(Equal(expected))
+					Expect(timeTarget.Custom).To(Equal(expected))
+					Expect(timeTarget.List).To(Equal([]time.Time{expected, expected.AddDate(0, 0, 1)}))
+					Expect(timeTarget.Name).To(Equal("2021-01-01"))
+				})
+				It("searches for all base names in order", func() {
+					c.Files.BaseNames = []string{"legacy", "other"}
+					Expect(os.WriteFile(path.Join(dir, "legacy.json"), []byte(`{"port":1}`), 0600)).To(Succeed())
+					Expect(os.WriteFile(path.Join(dir, baseFileName+".json"), []byte(`{"port":2}`), 0600)).To(Succeed())
+
+					Expect(c.readFiles(fields)).To(Succeed())
+					Expect(target.V).To(Equal(1))
+
+					c.Files.BaseName = ""
+					c.Files.BaseNames = []string{"legacy", baseFileName}
+					Expect(c.readFiles(fields)).To(Succeed())
+					Expect(target.V).To(Equal(2))
+				})
+				It("supports glob patterns in locations", func() {
+					confDir := path.Join(dir, "conf.d")
+					Expect(os.Mkdir(confDir, 0700)).To(Succeed())
+					Expect(os.WriteFile(path.Join(confDir, "10-base.json"), []byte(`{"port":1,"anything":1}`), 0600)).To(Succeed())
+					Expect(os.WriteFile(path.Join(confDir, "20-override.yaml"), []byte(`port: 2`), 0600)).To(Succeed())
+					Expect(os.WriteFile(path.Join(confDir, "ignored.txt"), []byte(`port: 3`), 0600)).To(Succeed())
+
+					c.Files.Locations = []string{path.Join(confDir, "*-*")}
+					Expect(c.readFiles(fields)).To(Succeed())
+					Expect(target.V).To(Equal(2))
+				})
+				It("returns error on malformed glob patterns", func() {
+					c.Files.Locations = []string{path.Join(dir, "[")}
+					Expect(c.readFiles(fields)).To(MatchError(filepath.ErrBadPattern))
+				})
+				It("skips locations that don't exist", func() {
+					Expect(os.WriteFile(path.Join(dir, baseFileName), []byte(`{"port":3000}`), 0600)).To(Succeed())
+					c.Files.Locations = append(c.Files.Locations, path.Join(dir, "not-existing"))
+
+					Expect(c.readFiles(fields)).To(Succeed())
+					Expect(target.V).To(Equal(3000))
+				})
+				It("returns error for keys that only differ in case, underscores or dashes", func() {
+					Expect(os.WriteFile(path.Join(dir, baseFileName+".json"), []byte(`{"port":1,"PORT":2}`), 0600)).
+						To(Succeed())
+
+					Expect(c.readFiles(fields)).To(MatchError(ErrDuplicateKey))
+				})
+				It("keeps keys of map fields that only differ in case, underscores or dashes", func() {
+					mapTarget := struct {
+						Labels map[string]string
+						Hosts  map[string]string
+					}{}
+					mapFields, err := getFieldsConfigsFromValue(reflect.ValueOf(&mapTarget).Elem())
+					Expect(err).ShouldNot(HaveOccurred())
+
+					Expect(os.WriteFile(path.Join(dir, baseFileName+".yaml"),
+						[]byte("labels: {Foo: a, foo: b}\nhosts: {api_v1: a, apiv1: b}\n"), 0600)).To(Succeed())
+
+					Expect(c.readFiles(mapFields)).To(Succeed())
+					Expect(mapTarget.Labels).To(Equal(map[string]string{"Foo": "a", "foo": "b"}))
+					Expect(mapTarget.Hosts).To(Equal(map[string]string{"api_v1": "a", "apiv1": "b"}))
+				})
+				It("skips locations that are files instead of directories", func() {
+					Expect(os.WriteFile(path.Join(dir, baseFileName), []byte(`{"port":3000}`), 0600)).To(Succeed())
+					notADir := path.Join(dir, "not-a-dir")
+					Expect(os.WriteFile(notADir, []byte(`{"port":3001}`), 0600)).To(Succeed())
+					c.Files.Locations = append(c.Files.Locations, notADir)
+
+					Expect(c.readFiles(fields)).To(Succeed())
 					Expect(target.V).To(Equal(3000))
 				})
+				It("returns error for locations that can't be read", func() {
+					// a location below a file can't be read
+					notADir := path.Join(dir, "not-a-dir")
+					Expect(os.WriteFile(notADir, []byte(`{"port":3000}`), 0600)).To(Succeed())
+					c.Files.Locations = []string{path.Join(notADir, "sub")}
+
+					err := c.readFiles(fields)
+					Expect(err).Should(HaveOccurred())
+					Expect(err).ShouldNot(Equal(ErrNoFileFound))
+				})
+				It("skips locations that can't be read if configured", func() {
+					notADir := path.Join(dir, "not-a-dir")
+					Expect(os.WriteFile(notADir, []byte(`{"port":3000}`), 0600)).To(Succeed())
+					c.Files.Locations = []string{path.Join(notADir, "sub")}
+					c.Files.IgnoreReadErrors = true
+
+					Expect(c.readFiles(fields)).To(Equal(ErrNoFileFound))
+				})
+				It("supports files with byte order mark and windows line endings", func() {
+					jsonBytes := []byte("\xEF\xBB\xBF{\r\n  \"port\": 3000\r\n}\r\n")
+					Expect(os.WriteFile(path.Join(dir, baseFileName), jsonBytes, 0600)).To(Succeed())
+
+					Expect(c.readFiles(fields)).To(Succeed())
+					Expect(target.V).To(Equal(3000))
+
+					iniBytes := []byte("\xEF\xBB\xBFport = 3001\r\n")
+					Expect(os.WriteFile(path.Join(dir, baseFileName), iniBytes, 0600)).To(Succeed())
+
+					Expect(c.readFiles(fields)).To(Succeed())
+					Expect(target.V).To(Equal(3001))
+				})
+				It("returns error for empty files if configured", func() {
+					Expect(os.WriteFile(path.Join(dir, baseFileName+".yaml"), []byte(" \n"), 0600)).To(Succeed())
+					Expect(c.readFiles(fields)).To(Succeed())
+
+					c.Files.ErrorOnEmpty = true
+					Expect(c.readFiles(fields)).To(MatchError(ErrEmptyFile))
+				})
 				It("is case insensitive", func() {
 					jsonBytes := []byte(`{"PORT":3000}`)
-					Expect(ioutil.WriteFile(path.Join(dir, baseFileName), jsonBytes, 0600)).To(Succeed())
+					Expect(os.WriteFile(path.Join(dir, baseFileName), jsonBytes, 0600)).To(Succeed())
 
-					Expect(readFiles(fields, config)).To(Succeed())
+					Expect(c.readFiles(fields)).To(Succeed())
 					Expect(target.V).To(Equal(3000))
 				})
 			})
 
 			Describe("readFileMap", func() {
 				var m *ciMap
+				var c *Collector
 				BeforeEach(func() {
 					m = &ciMap{separator: separator}
+					c = &Collector{Files: FilesConfig{Separator: separator}}
 				})
 				It("tries to cast from string if type mismatch", func() {
 					m.m = map[string]interface{}{"port": "1234"}
 
-					Expect(readFileMap(fields, separator, m)).To(Succeed())
+					Expect(c.readFileMap(fields, m)).To(Succeed())
 					Expect(target.V).To(Equal(1234))
 				})
+				It("returns an error instead of panicking for nil pointers to non-struct types", func() {
+					nilTarget := &struct{ P *int }{}
+
+					Expect(c.GetFromMap(nilTarget, map[string]interface{}{"other": 1})).To(Succeed())
+					Expect(c.GetFromMap(nilTarget, map[string]interface{}{"p": 1})).To(MatchError(ErrCantSet))
+				})
+				It("uses json and yaml tags as fallback for the file keys", func() {
+					tagTarget := &struct {
+						DB struct {
+							MaxConns int `json:"max_conns"`
+							Host     string
+						} `yaml:"database"`
+						Port int `json:"port" config:"file=listen"`
+					}{}
+					c.Files.Naming = SnakeCase
+
+					Expect(c.GetFromMap(tagTarget, map[string]interface{}{
+						"database": map[string]interface{}{"max_conns": 3, "host": "db"},
+						"listen":   1,
+						"port":     2,
+					})).To(Succeed())
+					Expect(tagTarget.DB.MaxConns).To(Equal(3))
+					Expect(tagTarget.DB.Host).To(Equal("db"))
+					Expect(tagTarget.Port).To(Equal(2))
+				})
+				It("tries to cast from number if type mismatch", func() {
+					timeTarget := &struct{ V time.Time }{}
+					timeFields := []*field{{Name: "ts", Value: wrappedValue(timeTarget), Config: parameterConfig{TimeFormat: "unix"}}}
+					m.m = map[string]interface{}{"ts": float64(1167750245)}
+
+					Expect(c.readFileMap(timeFields, m)).To(Succeed())
+					Expect(timeTarget.V.Equal(time.Date(2007, 1, 2, 15, 4, 5, 0, time.UTC))).To(BeTrue())
+				})
+				It("decodes durations from strings and numbers", func() {
+					durationTarget := &struct {
+						V struct {
+							Timeout  time.Duration
+							Backoffs []time.Duration
+							Limits   map[string]time.Duration
+						}
+					}{}
+					durationFields := []*field{{Name: "v", Value: wrappedValue(durationTarget)}}
+					m.m = map[string]interface{}{"v": map[string]interface{}{
+						"timeout":  5000000000,
+						"backoffs": []interface{}{"1s", 2000000000},
+						"limits":   map[string]interface{}{"read": "1m"},
+					}}
+
+					Expect(c.readFileMap(durationFields, m)).To(Succeed())
+					Expect(durationTarget.V.Timeout).To(Equal(5 * time.Second))
+					Expect(durationTarget.V.Backoffs).To(Equal([]time.Duration{time.Second, 2 * time.Second}))
+					Expect(durationTarget.V.Limits).To(Equal(map[string]time.Duration{"read": time.Minute}))
+				})
+				It("converts strings in nested values like env values", func() {
+					stringTarget := &struct {
+						V struct {
+							Since time.Time
+							IPs   []net.IP
+							Tags  []string
+							Env   map[string]string
+						}
+					}{}
+					stringFields := []*field{{Name: "v", Value: wrappedValue(stringTarget)}}
+					m.m = map[string]interface{}{"v": map[string]interface{}{
+						"since": "2007-01-02T15:04:05Z",
+						"ips":   []interface{}{"127.0.0.1", "::1"},
+						"tags":  "a,b",
+						"env":   "a=b",
+					}}
+
+					Expect(c.readFileMap(stringFields, m)).To(Succeed())
+					Expect(stringTarget.V.Since.Equal(time.Date(2007, 1, 2, 15, 4, 5, 0, time.UTC))).To(BeTrue())
+					Expect(stringTarget.V.IPs).To(Equal([]net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("::1")}))
+					Expect(stringTarget.V.Tags).To(Equal([]string{"a", "b"}))
+					Expect(stringTarget.V.Env).To(Equal(map[string]string{"a": "b"}))
+				})
+				It("converts map keys", func() {
+					mapTarget := &struct {
+						V struct {
+							Names  map[int]string
+							Limits map[string]int
+						}
+					}{}
+					mapFields := []*field{{Name: "v", Value: wrappedValue(mapTarget)}}
+					m.m = map[string]interface{}{"v": map[string]interface{}{
+						"names":  map[string]interface{}{"1": "a", "2": "b"},
+						"limits": map[string]interface{}{"cpu": 2},
+					}}
+
+					Expect(c.readFileMap(mapFields, m)).To(Succeed())
+					Expect(mapTarget.V.Names).To(Equal(map[int]string{1: "a", 2: "b"}))
+					Expect(mapTarget.V.Limits).To(Equal(map[string]int{"cpu": 2}))
+				})
+				It("decodes types implementing json.Unmarshaler", func() {
+					jsonTarget := &struct {
+						V struct {
+							J testJSONType
+						}
+					}{}
+					jsonFields := []*field{{Name: "v", Value: wrappedValue(jsonTarget)}}
+					m.m = map[string]interface{}{"v": map[string]interface{}{
+						"j": map[string]interface{}{"b": "x", "a": "y"},
+					}}
+
+					Expect(c.readFileMap(jsonFields, m)).To(Succeed())
+					Expect(jsonTarget.V.J.Names).To(Equal([]string{"a", "b"}))
+				})
 				It("returns error if type mismatch and yaml type is not a string", func() {
 					m.m = map[string]interface{}{"port": []string{"1234"}}
 
-					Expect(readFileMap(fields, separator, m)).NotTo(Succeed())
+					Expect(c.readFileMap(fields, m)).NotTo(Succeed())
 				})
 				It("uses configured overwrite long name", func() {
 					fields[0].Config.DefaultFileField = "overwrite"
 					m.m = map[string]interface{}{"overwrite": 3000}
 
-					Expect(readFileMap(fields, separator, m)).To(Succeed())
+					Expect(c.readFileMap(fields, m)).To(Succeed())
 					Expect(target.V).To(Equal(3000))
 				})
+				It("normalizes tag values, field names and file keys the same way", func() {
+					mixedTarget := struct {
+						DbHost string `config:"file=DB_Host"`
+						DBPort int
+					}{}
+					mixedFields, err := getFieldsConfigsFromValue(reflect.ValueOf(&mixedTarget).Elem())
+					Expect(err).ShouldNot(HaveOccurred())
+
+					m.m = map[string]interface{}{"db-host": "localhost", "db_port": 5432}
+					Expect(c.readFileMap(mixedFields, m)).To(Succeed())
+					Expect(mixedTarget.DbHost).To(Equal("localhost"))
+					Expect(mixedTarget.DBPort).To(Equal(5432))
+
+					m.m = map[string]interface{}{"DBHOST": "127.0.0.1"}
+					Expect(c.readFileMap(mixedFields, m)).To(Succeed())
+					Expect(mixedTarget.DbHost).To(Equal("127.0.0.1"))
+				})
 				It("doesn't overwrite with empty value if not set", func() {
 					target.V = 3000
 
-					Expect(readFileMap(fields, separator, m)).To(Succeed())
+					Expect(c.readFileMap(fields, m)).To(Succeed())
 					Expect(target.V).To(Equal(3000))
 				})
 				It("overwrites with empty value if set to empty", func() {
 					target.V = 3000
 					m.m = map[string]interface{}{"port": 0}
 
-					Expect(readFileMap(fields, separator, m)).To(Succeed())
+					Expect(c.readFileMap(fields, m)).To(Succeed())
 					Expect(target.V).To(Equal(0))
 				})
 				Context("nested", func() {
 					It("works", func() {
 						m.m = map[string]interface{}{"sub": map[string]interface{}{"port": 1234}}
 
-						Expect(readFileMap(nestedFields, separator, m)).To(Succeed())
+						Expect(c.readFileMap(nestedFields, m)).To(Succeed())
 						Expect(nestedTarget.Sub.V).To(Equal(1234))
 					})
+					It("applies whole struct values before child keys", func() {
+						m.m = map[string]interface{}{
+							"sub.port": 2,
+							"sub":      map[string]interface{}{"port": 1},
+						}
+
+						Expect(c.readFileMap(nestedFields, m)).To(Succeed())
+						Expect(nestedTarget.Sub.V).To(Equal(2))
+					})
+					It("merges whole struct values into the defaults", func() {
+						serverTarget := struct {
+							Server struct {
+								Host string
+								Port int
+							}
+						}{}
+						serverTarget.Server.Host = "localhost"
+						serverFields, err := getFieldsConfigsFromValue(reflect.ValueOf(&serverTarget).Elem())
+						Expect(err).ShouldNot(HaveOccurred())
+
+						m.m = map[string]interface{}{"server": map[string]interface{}{"port": 1}}
+
+						Expect(c.readFileMap(serverFields, m)).To(Succeed())
+						Expect(serverTarget.Server.Host).To(Equal("localhost"))
+						Expect(serverTarget.Server.Port).To(Equal(1))
+					})
 					It("can be targeted with overwrite", func() {
 						nestedFields[0].Config.DefaultFileField = "sub.port"
 						m.m = map[string]interface{}{"sub": map[string]interface{}{"port": 1234}}
 
-						Expect(readFileMap(nestedFields, separator, m)).To(Succeed())
+						Expect(c.readFileMap(nestedFields, m)).To(Succeed())
 						Expect(nestedTarget.Sub.V).To(Equal(1234))
 					})
+					It("can target nested paths from flat fields", func() {
+						fields[0].Config.DefaultFileField = "server.http.port"
+						m.m = map[string]interface{}{
+							"server": map[string]interface{}{"http": map[string]interface{}{"port": 1234}},
+						}
+
+						Expect(c.readFileMap(fields, m)).To(Succeed())
+						Expect(target.V).To(Equal(1234))
+					})
+					It("can target keys containing the separator", func() {
+						fields[0].Config.DefaultFileField = "server.http.port"
+						m.m = map[string]interface{}{"Server.HTTP": map[string]interface{}{"port": 1234}}
+
+						Expect(c.readFileMap(fields, m)).To(Succeed())
+						Expect(target.V).To(Equal(1234))
+					})
 					It("can be overridden", func() {
 						nestedFields[0].Config.DefaultFileField = "default"
 						m.m = map[string]interface{}{"default": 1234}
 
-						Expect(readFileMap(nestedFields, separator, m)).To(Succeed())
+						Expect(c.readFileMap(nestedFields, m)).To(Succeed())
 						Expect(nestedTarget.Sub.V).To(Equal(1234))
 					})
 					It("uses distinct name instead of overridden/default if both are set", func() {
 						nestedFields[0].Config.DefaultFileField = "default"
 						m.m = map[string]interface{}{"default": 1234, "sub": map[string]interface{}{"port": 1235}}
 
-						Expect(readFileMap(nestedFields, separator, m)).To(Succeed())
+						Expect(c.readFileMap(nestedFields, m)).To(Succeed())
 						Expect(nestedTarget.Sub.V).To(Equal(1235))
 					})
 					It("works if multiple fields are trying to get the same default flag", func() {
@@ -440,7 +1484,7 @@ test:
 						nestedFields[1].Config.DefaultFileField = "default"
 						m.m = map[string]interface{}{"default": 1234, "sub": map[string]interface{}{"port": 1235}}
 
-						Expect(readFileMap(nestedFields, separator, m)).To(Succeed())
+						Expect(c.readFileMap(nestedFields, m)).To(Succeed())
 						Expect(nestedTarget.Sub.V).To(Equal(1235))
 						Expect(nestedTarget.Sub.W).To(Equal(1234))
 					})
@@ -474,6 +1518,68 @@ test:
 			Expect(func() { _, _ = readParameterConfig("file=") }).To(Panic())
 			Expect(func() { _, _ = readParameterConfig("env") }).To(Panic())
 		})
+		It("reads base64 encoding", func() {
+			p, err := readParameterConfig("base64=rawurl")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(p.Base64Encoding).To(Equal(base64.RawURLEncoding))
+
+			_, err = readParameterConfig("base64=unknown")
+			Expect(err).To(Equal(ErrUnknownEncoding))
+		})
+		It("reads time format", func() {
+			p, err := readParameterConfig("timeformat=unixmilli")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(p.TimeFormat).To(Equal("unixmilli"))
+
+			p, err = readParameterConfig("timeformat=02.01.2006 15:04")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(p.TimeFormat).To(Equal("02.01.2006 15:04"))
+
+			p, err = readParameterConfig("timeformat=Jan")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(p.TimeFormat).To(Equal("Jan"))
+
+			_, err = readParameterConfig("timeformat=unknown")
+			Expect(err).To(Equal(ErrUnknownTimeFormat))
+
+			_, err = readParameterConfig("timeformat=build 9")
+			Expect(err).To(Equal(ErrUnknownTimeFormat))
+		})
+		It("reads separators", func() {
+			p, err := readParameterConfig("env=TAGS,sep=;,kvsep==")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(p).To(Equal(parameterConfig{DefaultEnvName: "TAGS", ListSeparator: ";", KeyValueSeparator: "="}))
+		})
+		It("reads bounds", func() {
+			p, err := readParameterConfig("env=WORKERS,min=1,max=64")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(p).To(Equal(parameterConfig{DefaultEnvName: "WORKERS", Min: "1", Max: "64"}))
+
+			_, err = readParameterConfig("min=one")
+			Expect(errors.Is(err, ErrInvalidBound)).To(BeTrue())
+		})
+		It("reads allowed values", func() {
+			p, err := readParameterConfig("env=LOG_LEVEL,oneof=debug info warn,ignorecase")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(p).To(Equal(parameterConfig{
+				DefaultEnvName: "LOG_LEVEL",
+				OneOf:          []string{"debug", "info", "warn"},
+				IgnoreCase:     true,
+			}))
+		})
+		It("reads keys without values", func() {
+			p, err := readParameterConfig("env=MAX_UPLOAD,bytesize")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(p).To(Equal(parameterConfig{DefaultEnvName: "MAX_UPLOAD", ByteSize: true}))
+
+			p, err = readParameterConfig("flag=v,count")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(p).To(Equal(parameterConfig{Flag: flag{ShortName: "v"}, Count: true}))
+
+			p, err = readParameterConfig("env=FEATURES,json")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(p).To(Equal(parameterConfig{DefaultEnvName: "FEATURES", JSON: true}))
+		})
 		It("works with valid format configStr, allows whitespace", func() {
 			p, err := readParameterConfig("file=val,env=val,flag=l long")
 			Expect(err).ShouldNot(HaveOccurred())
@@ -488,6 +1594,35 @@ test:
 		})
 	})
 	Describe("getFieldsConfigsFromValue", func() {
+		It("reads the file names from json and yaml tags", func() {
+			target := struct {
+				Sub struct {
+					Port    int `yaml:"port"`
+					Timeout int `json:"timeout,omitempty" yaml:"time"`
+					Host    int `json:"-"`
+				} `json:"sub"`
+			}{}
+			fields, err := getFieldsConfigsFromValue(reflect.ValueOf(target))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(fields).To(HaveLen(4))
+			Expect(fields[1].FileNames).To(Equal([]string{"sub", "port"}))
+			Expect(fields[2].FileNames).To(Equal([]string{"sub", "timeout"}))
+			Expect(fields[3].FileNames).To(Equal([]string{"sub", ""}))
+			Expect(fields[3].fileNameWith(".", SnakeCase)).To(Equal("sub.host"))
+		})
+		It("doesn't recurse into structs that implement an unmarshaler", func() {
+			target := struct {
+				Text    testType
+				JSON    *testJSONType
+				Created time.Time
+			}{JSON: &testJSONType{}}
+			fields, err := getFieldsConfigsFromValue(reflect.ValueOf(target))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(fields).To(HaveLen(3))
+			Expect(fields[0].Name).To(Equal("Text"))
+			Expect(fields[1].Name).To(Equal("JSON"))
+			Expect(fields[2].Name).To(Equal("Created"))
+		})
 		It("gets correct fields, supports nested struct", func() {
 			target := struct {
 				Sub struct {
@@ -498,15 +1633,19 @@ test:
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(fields).To(Equal([]*field{
 				{
-					Base:   nil,
-					Name:   "Sub",
-					Value:  reflect.ValueOf(target.Sub),
-					Config: parameterConfig{},
+					Base:      nil,
+					Name:      "Sub",
+					FileNames: []string{""},
+					Type:      reflect.TypeOf(target.Sub),
+					Value:     reflect.ValueOf(target.Sub),
+					Config:    parameterConfig{},
 				},
 				{
-					Base:  []string{"Sub"},
-					Name:  "Port",
-					Value: reflect.ValueOf(target.Sub.Port),
+					Base:      []string{"Sub"},
+					Name:      "Port",
+					FileNames: []string{"", ""},
+					Type:      reflect.TypeOf(target.Sub.Port),
+					Value:     reflect.ValueOf(target.Sub.Port),
 					Config: parameterConfig{
 						DefaultEnvName: "test",
 					},
@@ -521,7 +1660,7 @@ test:
 			BeforeEach(func() {
 				var err error
 				// create temp dir
-				tempDir, err = ioutil.TempDir("", "tests*")
+				tempDir, err = os.MkdirTemp("", "tests*")
 				Expect(err).ShouldNot(HaveOccurred())
 
 				c = &Collector{
@@ -551,17 +1690,233 @@ test:
 				Expect(err).Should(HaveOccurred())
 				Expect(err).To(Equal(ErrPointerExpected))
 			})
+			It("returns error if v is a nil pointer", func() {
+				type Config struct{ Port int }
+
+				var cfg *Config
+				Expect((&Collector{}).Get(cfg)).To(Equal(ErrNilPointer))
+				Expect((&Collector{}).GetFromMap(cfg, nil)).To(Equal(ErrNilPointer))
+				Expect((&Collector{}).Save(cfg, path.Join(tempDir, "config.json"))).To(Equal(ErrNilPointer))
+			})
 			It("works if v is a pointer", func() {
 				err := (&Collector{}).Get(&struct{}{})
 				Expect(err).ShouldNot(HaveOccurred())
 			})
+			It("is safe for concurrent use", func() {
+				Expect(os.WriteFile(path.Join(tempDir, c.Files.BaseName), []byte(`{"port": 2}`), 0600)).To(Succeed())
+
+				shared := test.APIConfig{}
+				errs := make(chan error)
+
+				for i := 0; i < 10; i++ {
+					go func() {
+						separate := test.APIConfig{}
+						if err := c.Get(&separate); err != nil || separate.Port != 2 {
+							errs <- fmt.Errorf("unexpected result %v: %w", separate, err)
+							return
+						}
+
+						errs <- c.Get(&shared)
+					}()
+				}
+
+				for i := 0; i < 10; i++ {
+					Expect(<-errs).ShouldNot(HaveOccurred())
+				}
+
+				Expect(shared.Port).To(Equal(2))
+			})
+			Describe("GetFromMap", func() {
+				It("returns error if v is not a pointer", func() {
+					Expect(c.GetFromMap(struct{}{}, nil)).To(Equal(ErrPointerExpected))
+				})
+				It("reads the values like from a file", func() {
+					testingStruct := struct {
+						Timeout time.Duration
+						API     struct{ Port int }
+					}{}
+
+					Expect(c.GetFromMap(&testingStruct, map[string]interface{}{
+						"timeout": "5s",
+						"API":     map[interface{}]interface{}{"port": 1},
+					})).To(Succeed())
+					Expect(testingStruct.Timeout).To(Equal(5 * time.Second))
+					Expect(testingStruct.API.Port).To(Equal(1))
+
+					Expect(c.GetFromMap(&testingStruct, map[string]interface{}{"api.port": 2})).To(Succeed())
+					Expect(testingStruct.API.Port).To(Equal(2))
+				})
+				It("returns error for unknown keys in strict mode", func() {
+					c.Files.Strict = true
+					err := c.GetFromMap(&struct{ Port int }{}, map[string]interface{}{"prot": 1})
+					Expect(errors.Is(err, ErrUnknownKey)).To(BeTrue())
+				})
+			})
+			It("reports the loaded files", func() {
+				Expect(c.Get(&test.APIConfig{})).To(Succeed())
+				Expect(c.LoadedFiles()).To(BeEmpty())
+
+				Expect(os.WriteFile(path.Join(tempDir, "config.json"), []byte(`{"port": 2}`), 0600)).To(Succeed())
+				Expect(os.WriteFile(path.Join(tempDir, "config.yaml"), []byte(`port: 3`), 0600)).To(Succeed())
+
+				Expect(c.Get(&test.APIConfig{})).To(Succeed())
+				Expect(c.LoadedFiles()).To(Equal([]string{
+					path.Join(tempDir, "config.json"),
+					path.Join(tempDir, "config.yaml"),
+				}))
+			})
+			It("proceeds with env and flags if no file is found", func() {
+				c.Flags.Args = []string{"-p", "5"}
+				testingStruct := test.APIConfig{}
+
+				Expect(c.Get(&testingStruct)).To(Succeed())
+				Expect(testingStruct.Port).To(Equal(5))
+			})
+			It("returns error if a file can't be parsed", func() {
+				Expect(os.WriteFile(path.Join(tempDir, "config.json"), []byte(`{"port": `), 0600)).To(Succeed())
+				c.Flags.Args = []string{"-p", "5"}
+				testingStruct := test.APIConfig{}
+
+				Expect(c.Get(&testingStruct)).To(MatchError(ErrFileTypeNotSupported))
+				Expect(testingStruct.Port).To(Equal(0))
+			})
+			It("uses configured args instead of os.Args", func() {
+				c.Flags.Args = []string{"-p", "5"}
+				testingStruct := test.APIConfig{}
+
+				Expect(c.Get(&testingStruct)).To(Succeed())
+				Expect(testingStruct.Port).To(Equal(5))
+			})
+			Context("duplicate names", func() {
+				It("returns error for duplicate generated env names", func() {
+					testingStruct := struct {
+						Db_Host string // nolint: golint,stylecheck // underscore to produce collision
+						Db      struct{ Host string }
+					}{}
+					err := c.Get(&testingStruct)
+					Expect(err).To(MatchError(ErrDuplicateName))
+					Expect(err.Error()).To(ContainSubstring(`"DB_HOST" of Db.Host is already used by Db_Host`))
+
+					c.Env.Disabled = true
+					Expect(c.Get(&testingStruct)).To(Succeed())
+				})
+				It("uses only explicit env names for fields with duplicate generated env names", func() {
+					type dbConn struct {
+						Host string `config:"env=DBCONN_HOST"`
+					}
+					testingStruct := struct {
+						Db_Conn dbConn `config:"env=DBCONN"` // nolint: golint,stylecheck // underscore to produce collision
+						Db      struct{ Conn struct{ Host string } }
+					}{}
+					Expect(os.Setenv("DBCONN_HOST", "explicit")).To(Succeed())
+					defer os.Unsetenv("DBCONN_HOST")
+					Expect(os.Setenv("DB_CONN_HOST", "generated")).To(Succeed())
+					defer os.Unsetenv("DB_CONN_HOST")
+
+					Expect(c.Get(&testingStruct)).To(Succeed())
+					Expect(testingStruct.Db_Conn.Host).To(Equal("explicit"))
+					Expect(testingStruct.Db.Conn.Host).To(Equal("generated"))
+				})
+				It("returns error for duplicate flag shorthands", func() {
+					testingStruct := struct {
+						A int `config:"flag=p"`
+						B int `config:"flag=p"`
+					}{}
+					Expect(c.Get(&testingStruct)).To(MatchError(ErrDuplicateName))
+				})
+				It("returns error if generated flag name collides with a defined one", func() {
+					testingStruct := struct {
+						Port int
+						API  struct {
+							Port int `config:"flag=port"`
+						}
+					}{}
+					Expect(c.Get(&testingStruct)).To(MatchError(ErrDuplicateName))
+				})
+				It("allows a field to define its generated flag name", func() {
+					testingStruct := struct {
+						Port int `config:"flag=port"`
+					}{}
+					c.Flags.Args = []string{"--port", "1"}
+					Expect(c.Get(&testingStruct)).To(Succeed())
+					Expect(testingStruct.Port).To(Equal(1))
+
+					shorthandStruct := struct {
+						Port int `config:"flag=p port"`
+					}{}
+					c.Flags.Args = []string{"-p", "2"}
+					Expect(c.Get(&shorthandStruct)).To(Succeed())
+					Expect(shorthandStruct.Port).To(Equal(2))
+				})
+				It("doesn't register negated flags that collide with another flag", func() {
+					testingStruct := struct {
+						Cache   bool
+						NoCache bool `config:"flag=no-cache"`
+					}{}
+					c.Flags.Args = []string{"--cache", "--no-cache"}
+					Expect(c.Get(&testingStruct)).To(Succeed())
+					Expect(testingStruct.Cache).To(BeTrue())
+					Expect(testingStruct.NoCache).To(BeTrue())
+
+					kebabStruct := struct {
+						Cache   bool
+						NoCache bool
+						Verbose bool
+					}{Verbose: true}
+					c.Flags.Naming = KebabCase
+					c.Flags.Args = []string{"--no-cache", "--no-verbose"}
+					Expect(c.Get(&kebabStruct)).To(Succeed())
+					Expect(kebabStruct.Cache).To(BeFalse())
+					Expect(kebabStruct.NoCache).To(BeTrue())
+					Expect(kebabStruct.Verbose).To(BeFalse())
+				})
+				It("returns error for file variables that are the names of other fields if configured", func() {
+					testingStruct := struct {
+						Log      struct{ File string }
+						Cert     string
+						CertFile string
+					}{}
+					c.Env.FileSuffix = "_FILE"
+					c.Env.SnakeCase = true
+					Expect(c.Get(&testingStruct)).To(Succeed())
+
+					c.Env.UniqueNames = true
+					err := c.Get(&testingStruct)
+					Expect(err).To(MatchError(ErrDuplicateName))
+					Expect(err.Error()).To(ContainSubstring(`file variable "CERT_FILE" of Cert is already used by CertFile`))
+				})
+				It("allows fields to share defined names", func() {
+					testingStruct := struct {
+						A int `config:"env=SHARED,flag=shared"`
+						B int `config:"env=SHARED,flag=shared"`
+					}{}
+					Expect(c.Get(&testingStruct)).To(Succeed())
+				})
+				It("returns error for shared defined env names and file keys if configured", func() {
+					testingStruct := struct {
+						A int `config:"env=TOKEN,file=token"`
+						B int `config:"env=TOKEN|OTHER,file=other|token"`
+					}{}
+
+					c.Env.UniqueNames = true
+					err := c.Get(&testingStruct)
+					Expect(err).To(MatchError(ErrDuplicateName))
+					Expect(err.Error()).To(ContainSubstring(`explicit environment variable "TOKEN" of B is already used by A`))
+
+					c.Env.UniqueNames = false
+					c.Files.UniqueNames = true
+					err = c.Get(&testingStruct)
+					Expect(err).To(MatchError(ErrDuplicateName))
+					Expect(err.Error()).To(ContainSubstring(`explicit file key "token" of B is already used by A`))
+				})
+			})
 			It("supports pointers for properties", func() {
 				testingStruct := testingConfigPointers{
 					API: &test.APIConfig{Port: 1, LogLevel: "info"},
 					DB:  &test.DBConfig{LogLevel: "info"},
 				}
 				jsonBytes := []byte(`{"logLevel": "default", "api": {"port": 2, "logLevel": "specified"}}`)
-				Expect(ioutil.WriteFile(path.Join(tempDir, c.Files.BaseName), jsonBytes, 0600)).To(Succeed())
+				Expect(os.WriteFile(path.Join(tempDir, c.Files.BaseName), jsonBytes, 0600)).To(Succeed())
 
 				Expect(c.Get(&testingStruct)).To(Succeed())
 				Expect(testingStruct.API.Port).To(Equal(2))
@@ -574,7 +1929,7 @@ test:
 					DBConfig:  test.DBConfig{LogLevel: "info"},
 				}
 				jsonBytes := []byte(`{"logLevel": "default", "apiConfig": {"port": 2, "logLevel": "specified"}}`)
-				Expect(ioutil.WriteFile(path.Join(tempDir, c.Files.BaseName), jsonBytes, 0600)).To(Succeed())
+				Expect(os.WriteFile(path.Join(tempDir, c.Files.BaseName), jsonBytes, 0600)).To(Succeed())
 
 				Expect(c.Get(&testingStruct)).To(Succeed())
 				Expect(testingStruct.APIConfig.Port).To(Equal(2))
@@ -614,7 +1969,7 @@ test:
 					Context("file is set", func() {
 						BeforeEach(func() {
 							jsonBytes := []byte(`{"logLevel": "default", "sleep": "1s", "api": {"port": 2, "logLevel": "specifiedInFile"}}`)
-							Expect(ioutil.WriteFile(path.Join(tempDir, c.Files.BaseName), jsonBytes, 0600)).To(Succeed())
+							Expect(os.WriteFile(path.Join(tempDir, c.Files.BaseName), jsonBytes, 0600)).To(Succeed())
 						})
 						It("overrides defaults", func() {
 							Expect(c.Get(&testingStruct)).To(Succeed())
@@ -662,6 +2017,11 @@ test:
 				Expect(s.UnmarshalText([]byte("string, lol, lel"))).To(Succeed())
 				Expect([]string(s)).To(Equal([]string{"string", "lol", "lel"}))
 			})
+			It("keeps quoted elements", func() {
+				s := stringSlice{}
+				Expect(s.UnmarshalText([]byte(`"string, lol", lel`))).To(Succeed())
+				Expect([]string(s)).To(Equal([]string{"string, lol", "lel"}))
+			})
 		})
 		Describe("string map", func() {
 			It("works for Unmarshal", func() {
@@ -672,6 +2032,12 @@ test:
 					"field2": "lol",
 				}))
 			})
+			It("sorts the entries for Marshal", func() {
+				s := stringMap{"b": "2", "c": "3", "a": "1"}
+				text, err := s.MarshalText()
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(string(text)).To(Equal("a=1,b=2,c=3"))
+			})
 		})
 	})
 })
@@ -740,3 +2106,32 @@ func (t *testType) UnmarshalText(text []byte) error {
 
 	return nil
 }
+
+type testBinaryType struct {
+	B []byte
+}
+
+func (t *testBinaryType) UnmarshalBinary(data []byte) error {
+	t.B = data
+
+	return nil
+}
+
+type testJSONType struct {
+	Names []string
+}
+
+func (t *testJSONType) UnmarshalJSON(data []byte) error {
+	var m map[string]string
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+
+	for name := range m {
+		t.Names = append(t.Names, name)
+	}
+
+	sort.Strings(t.Names)
+
+	return nil
+}
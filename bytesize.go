@@ -0,0 +1,72 @@
+package alligotor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalidByteSize is returned if a value for a field with the bytesize struct tag key is not a valid byte size.
+var ErrInvalidByteSize = errors.New("invalid byte size")
+
+// parseByteSize parses a human readable byte size like 10MB or 1.5GiB into the number of bytes.
+// The decimal looking units (KB, MB, ...) are interpreted as binary units just like KiB, MiB, ...,
+// so 1KB equals 1024 bytes. Units are case insensitive and values without a unit are parsed as plain integers.
+// An error is returned if the size doesn't fit into an unsigned integer of the given bit size.
+func parseByteSize(value string, bitSize int) (uint64, error) {
+	value = strings.TrimSpace(value)
+
+	if size, err := strconv.ParseUint(value, 0, bitSize); err == nil || errors.Is(err, strconv.ErrRange) {
+		return size, err
+	}
+
+	unitIndex := strings.LastIndexFunc(value, func(r rune) bool { return !unicode.IsLetter(r) }) + 1
+	numberStr, unit := strings.TrimSpace(value[:unitIndex]), value[unitIndex:]
+
+	multiplier, ok := byteSizeMultiplier(unit)
+	if !ok {
+		return 0, fmt.Errorf("%w: unknown unit in %q", ErrInvalidByteSize, value)
+	}
+
+	number, err := strconv.ParseFloat(numberStr, 64)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidByteSize, value)
+	}
+
+	size := number * float64(multiplier)
+	if size >= math.Exp2(float64(bitSize)) {
+		return 0, &strconv.NumError{Func: "parseByteSize", Num: value, Err: strconv.ErrRange}
+	}
+
+	return uint64(size), nil
+}
+
+func byteSizeMultiplier(unit string) (uint64, bool) {
+	const (
+		kibi = 1 << (10 * (iota + 1))
+		mebi
+		gibi
+		tebi
+		pebi
+	)
+
+	switch strings.ToLower(unit) {
+	case "b":
+		return 1, true
+	case "k", "kb", "kib":
+		return kibi, true
+	case "m", "mb", "mib":
+		return mebi, true
+	case "g", "gb", "gib":
+		return gibi, true
+	case "t", "tb", "tib":
+		return tebi, true
+	case "p", "pb", "pib":
+		return pebi, true
+	default:
+		return 0, false
+	}
+}
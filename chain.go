@@ -0,0 +1,54 @@
+package alligotor
+
+import (
+	"reflect"
+)
+
+// CollectorChain applies multiple Collectors to the same config struct, see Chain.
+type CollectorChain struct {
+	collectors []*Collector
+}
+
+// Chain returns a CollectorChain that reads the sources of the collectors in the given order,
+// so the values of later Collectors take precedence over the values of earlier ones.
+// This can be used to combine different source configurations, e.g. environment variables with a shared
+// prefix and environment variables with a service specific prefix.
+func Chain(collectors ...*Collector) *CollectorChain {
+	return &CollectorChain{collectors: collectors}
+}
+
+// Get calls Collector.Get with the options for each Collector of the chain in order.
+// It stops at the first error. The constraints defined in the struct tags (e.g. min and max)
+// are checked once after all Collectors are applied, using the Groups of all Collectors.
+func (ch *CollectorChain) Get(v interface{}, opts ...Option) error {
+	value := reflect.ValueOf(v)
+	if err := checkPointer(value); err != nil {
+		return err
+	}
+
+	for _, c := range ch.collectors {
+		if err := c.getWithOptions(v, opts, false); err != nil {
+			return err
+		}
+	}
+
+	// map targets don't have fields with constraints
+	if value.Elem().Kind() == reflect.Map {
+		return nil
+	}
+
+	fields, err := getFieldsConfigsFromValue(reflect.Indirect(value))
+	if err != nil {
+		return err
+	}
+
+	groups := make(map[string]GroupRule)
+
+	for _, c := range ch.collectors {
+		for name, rule := range c.Groups {
+			groups[name] = rule
+		}
+	}
+
+	return validate(fields, groups)
+}
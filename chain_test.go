@@ -0,0 +1,63 @@
+package alligotor
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Chain", func() {
+	var base, service *Collector
+
+	BeforeEach(func() {
+		base = &Collector{
+			Files: FilesConfig{Disabled: true},
+			Env:   EnvConfig{Prefix: "CHAINCOMMON", Separator: "_"},
+			Flags: FlagsConfig{Disabled: true},
+		}
+		service = &Collector{
+			Files: FilesConfig{Disabled: true},
+			Env:   EnvConfig{Prefix: "CHAINSERVICE", Separator: "_"},
+			Flags: FlagsConfig{Disabled: true},
+		}
+	})
+	AfterEach(func() {
+		Expect(os.Unsetenv("CHAINCOMMON_HOST")).To(Succeed())
+		Expect(os.Unsetenv("CHAINCOMMON_WORKERS")).To(Succeed())
+		Expect(os.Unsetenv("CHAINSERVICE_WORKERS")).To(Succeed())
+	})
+
+	It("applies the collectors in order", func() {
+		Expect(os.Setenv("CHAINCOMMON_HOST", "common")).To(Succeed())
+		Expect(os.Setenv("CHAINCOMMON_WORKERS", "1")).To(Succeed())
+		Expect(os.Setenv("CHAINSERVICE_WORKERS", "2")).To(Succeed())
+
+		cfg := struct {
+			Host    string
+			Workers int
+		}{}
+
+		Expect(Chain(base, service).Get(&cfg)).To(Succeed())
+		Expect(cfg.Host).To(Equal("common"))
+		Expect(cfg.Workers).To(Equal(2))
+	})
+	It("validates the result of all collectors", func() {
+		Expect(os.Setenv("CHAINSERVICE_WORKERS", "2")).To(Succeed())
+
+		cfg := struct {
+			Workers int `config:"min=1"`
+		}{}
+
+		// base alone would fail since it doesn't set Workers
+		Expect(Chain(base, service).Get(&cfg)).To(Succeed())
+		Expect(cfg.Workers).To(Equal(2))
+
+		cfg.Workers = 0
+		Expect(Chain(base, service).Get(&cfg, WithoutEnv())).To(MatchError(ErrOutOfRange))
+	})
+	It("returns error if v is not a pointer", func() {
+		Expect(Chain(base).Get(struct{}{})).To(Equal(ErrPointerExpected))
+		Expect(Chain(base).Get((*struct{})(nil))).To(Equal(ErrNilPointer))
+	})
+})
@@ -1,7 +1,13 @@
 package alligotor
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -9,6 +15,13 @@ import (
 
 const defaultSeparator = "."
 
+// ErrDuplicateKey is returned if multiple keys of a config file that only differ in case, "_" or "-"
+// (e.g. db_host and dbhost) match the same field, since it would be undefined which of the values is used.
+var ErrDuplicateKey = errors.New("duplicate key")
+
+// keyNoiseReplacer removes the characters that are ignored when comparing keys.
+var keyNoiseReplacer = strings.NewReplacer("_", "", "-", "") // nolint: gochecknoglobals // immutable
+
 type ciMap struct {
 	m         map[string]interface{}
 	separator string
@@ -40,40 +53,239 @@ func (c ciMap) Set(s string, b bool) {
 	c.m[strings.ToLower(s)] = b
 }
 
+// Get returns the value for the key s.
+// The keys are compared after normalizing them with normalizeKey, so the lookup is case insensitive and
+// ignores "_" and "-" (e.g. DB_Host matches dbhost and db-host), which makes tag values and field names match
+// the same keys. Parts of s that are separated by the separator are resolved into nested maps, keys that contain
+// the separator themselves (e.g. "server.port" in a flat document) are matched as well, a full match takes precedence.
+// If multiple keys match, the value of the first one in lexical order is returned.
 func (c ciMap) Get(s string) (b interface{}, ok bool) {
-	substr := strings.Split(s, c.separator)
+	val, ok, _ := c.get(c.normalizeKey(s))
 
-	// go through map keys and check if key.ToLower() matches, field.ToLower()
-	for key := range c.m {
-		if !strings.EqualFold(key, substr[0]) {
-			continue
-		}
+	return val, ok
+}
 
-		val := c.m[key]
+// Lookup works like Get but returns ErrDuplicateKey if multiple keys match s, e.g. db_host and dbhost.
+// It's used to read the values of fields, only the keys on the path to the value are checked,
+// so keys within the value itself (e.g. of a map field) can still differ in case only.
+func (c ciMap) Lookup(s string) (interface{}, bool, error) {
+	return c.get(c.normalizeKey(s))
+}
 
-		if len(substr) == 1 {
-			// no separator in the string -> reached end of search string
-			return val, true
-		}
+func (c ciMap) get(normalized string) (interface{}, bool, error) {
+	if matches := c.matchingKeys(normalized, ""); len(matches) > 0 {
+		return c.m[matches[0]], true, duplicateKeyError(matches)
+	}
 
+	var (
+		found      interface{}
+		foundPaths []string
+	)
+
+	// keys need to match the beginning of s up to a separator
+	for _, key := range c.matchingKeys(normalized, c.separator) {
 		// iterate further through nested fields
-		valAsMap, ok := val.(map[string]interface{})
+		valAsMap, ok := c.m[key].(map[string]interface{})
 		if !ok {
-			return nil, false
+			continue
 		}
 
 		nestedCiMap := ciMap{m: valAsMap, separator: c.separator}
+		prefix := c.normalizeKey(key) + c.separator
 
-		return nestedCiMap.Get(strings.Join(substr[1:], c.separator))
+		nestedVal, ok, err := nestedCiMap.get(strings.TrimPrefix(normalized, prefix))
+		if err != nil {
+			return nestedVal, ok, err
+		}
+
+		if ok {
+			if foundPaths == nil {
+				found = nestedVal
+			}
+
+			foundPaths = append(foundPaths, key)
+		}
+	}
+
+	if foundPaths == nil {
+		return nil, false, nil
 	}
 
-	return nil, false
+	return found, true, duplicateKeyError(foundPaths)
+}
+
+// matchingKeys returns the sorted keys that are equal to normalized after normalizing them,
+// or that are a prefix of normalized followed by suffix if suffix is not empty.
+func (c ciMap) matchingKeys(normalized, suffix string) []string {
+	var keys []string
+
+	for key := range c.m {
+		normalizedKey := c.normalizeKey(key)
+		if suffix == "" && normalizedKey == normalized ||
+			suffix != "" && strings.HasPrefix(normalized, normalizedKey+suffix) {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// duplicateKeyError returns ErrDuplicateKey listing the keys if there is more than one.
+func duplicateKeyError(keys []string) error {
+	if len(keys) < 2 {
+		return nil
+	}
+
+	quoted := make([]string, len(keys))
+	for i, key := range keys {
+		quoted[i] = strconv.Quote(key)
+	}
+
+	return fmt.Errorf("%w: %s", ErrDuplicateKey, strings.Join(quoted, " and "))
+}
+
+// normalizeKey lower cases the key and removes all "_" and "-" that are not part of the separator.
+func (c ciMap) normalizeKey(key string) string {
+	parts := strings.Split(key, c.separator)
+	for i, part := range parts {
+		parts[i] = strings.ToLower(keyNoiseReplacer.Replace(part))
+	}
+
+	return strings.Join(parts, c.separator)
 }
 
 func (c *ciMap) UnmarshalYAML(value *yaml.Node) error {
-	return value.Decode(&c.m)
+	timestampsAsStrings(value)
+
+	if err := value.Decode(&c.m); err != nil {
+		return err
+	}
+
+	for key, val := range c.m {
+		c.m[key] = stringKeyMaps(val)
+	}
+
+	return nil
+}
+
+// timestampsAsStrings marks all timestamps in the yaml node as strings, so they're decoded as they're written
+// and parsed like quoted timestamps and values from env variables and flags, e.g. with the timeformat from the
+// struct tag. Otherwise unquoted timestamps would be decoded as time.Time already.
+func timestampsAsStrings(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!timestamp" {
+		node.Tag = "!!str"
+	}
+
+	for _, child := range node.Content {
+		timestampsAsStrings(child)
+	}
+}
+
+// unmarshalYAMLDocuments reads all documents of a yaml stream separated by "---" and merges them in order.
+// Nested mappings are merged recursively and keys are matched like in Get, all other values of later
+// documents override the values of earlier ones, e.g. lists are replaced and not appended.
+// That way a single file can contain a base configuration followed by overlays.
+func (c *ciMap) unmarshalYAMLDocuments(data []byte) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	merged := make(map[string]interface{})
+
+	for {
+		document := newCiMap(withSeparator(c.separator))
+
+		err := decoder.Decode(document)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		merged = c.merge(merged, document.m)
+	}
+
+	c.m = merged
+
+	return nil
+}
+
+// merge merges src into dst recursively, values of src take precedence.
+// Keys of src are only matched against the keys that dst had before, so keys of the same document that only
+// differ in case (e.g. of a map field) are kept.
+func (c ciMap) merge(dst, src map[string]interface{}) map[string]interface{} {
+	dstKeys := make([]string, 0, len(dst))
+	for dstKey := range dst {
+		dstKeys = append(dstKeys, dstKey)
+	}
+
+	for srcKey, srcVal := range src {
+		for _, dstKey := range dstKeys {
+			dstVal, ok := dst[dstKey]
+			if !ok || c.normalizeKey(dstKey) != c.normalizeKey(srcKey) {
+				continue
+			}
+
+			delete(dst, dstKey)
+
+			dstMap, dstIsMap := dstVal.(map[string]interface{})
+			if srcMap, srcIsMap := srcVal.(map[string]interface{}); dstIsMap && srcIsMap {
+				srcVal = c.merge(dstMap, srcMap)
+			}
+
+			break
+		}
+
+		dst[srcKey] = srcVal
+	}
+
+	return dst
+}
+
+// stringKeyMaps returns a copy of value in which all map[interface{}]interface{} values are recursively converted
+// to map[string]interface{}.
+// yaml decodes mappings that result from merge keys (<<: *base) as map[interface{}]interface{},
+// but nested lookups only work with string keys.
+func stringKeyMaps(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(typedValue))
+		for key, val := range typedValue {
+			m[fmt.Sprint(key)] = stringKeyMaps(val)
+		}
+
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(typedValue))
+		for key, val := range typedValue {
+			m[key] = stringKeyMaps(val)
+		}
+
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(typedValue))
+		for i, val := range typedValue {
+			s[i] = stringKeyMaps(val)
+		}
+
+		return s
+	default:
+		return value
+	}
 }
 
 func (c *ciMap) UnmarshalJSON(bytes []byte) error {
 	return json.Unmarshal(bytes, &c.m)
 }
+
+func (c *ciMap) UnmarshalINI(bytes []byte) error {
+	m, err := parseINI(c.separator, bytes)
+	if err != nil {
+		return err
+	}
+
+	c.m = m
+
+	return nil
+}
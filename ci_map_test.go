@@ -44,6 +44,26 @@ var _ = Describe("ciMap", func() {
 				Expect(val).To(Equal("idk"))
 			})
 		})
+		Context("underscores and dashes", func() {
+			It("ignores them in the key", func() {
+				val, ok := ciMap.Get("Test_2")
+				Expect(ok).To(BeTrue())
+				Expect(val).To(Equal("idk"))
+			})
+			It("ignores them in the map", func() {
+				ciMap.m = map[string]interface{}{"db_host": map[string]interface{}{"Port-Number": 1}}
+				val, ok := ciMap.Get("DbHost" + defaultSeparator + "portnumber")
+				Expect(ok).To(BeTrue())
+				Expect(val).To(Equal(1))
+			})
+			It("keeps them if they are the separator", func() {
+				ciMap = newCiMap(withSeparator("_"))
+				ciMap.m = map[string]interface{}{"db": map[string]interface{}{"host": "nested"}, "dbhost": "flat"}
+				val, ok := ciMap.Get("DB_Host")
+				Expect(ok).To(BeTrue())
+				Expect(val).To(Equal("nested"))
+			})
+		})
 		Context("key does not exist", func() {
 			It("should return ok=false", func() {
 				_, ok := ciMap.Get("not-existing")
@@ -51,4 +71,66 @@ var _ = Describe("ciMap", func() {
 			})
 		})
 	})
+	Describe("Lookup", func() {
+		It("returns error if multiple keys match", func() {
+			ciMap = newCiMap()
+			Expect(ciMap.unmarshalYAMLDocuments([]byte("db:\n  db_host: a\n  dbhost: b\nDB.Port: 1\ndb.port: 2\n"))).
+				To(Succeed())
+
+			_, _, err := ciMap.Lookup("db.db_host")
+			Expect(err).To(MatchError(`duplicate key: "db_host" and "dbhost"`))
+			_, _, err = ciMap.Lookup("db.port")
+			Expect(err).To(MatchError(ErrDuplicateKey))
+
+			val, ok := ciMap.Get("db.dbhost")
+			Expect(ok).To(BeTrue())
+			Expect(val).To(Equal("a"))
+		})
+		It("doesn't check the keys within the value", func() {
+			ciMap = newCiMap()
+			Expect(ciMap.unmarshalYAMLDocuments([]byte("labels:\n  Foo: a\n  foo: b\n"))).To(Succeed())
+
+			val, ok, err := ciMap.Lookup("labels")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(val).To(HaveLen(2))
+		})
+	})
+	Describe("unmarshalYAMLDocuments", func() {
+		It("merges all documents in order", func() {
+			ciMap = newCiMap()
+			Expect(ciMap.unmarshalYAMLDocuments([]byte(
+				"db:\n  host: base\n  port: 5432\ntags: [a, b]\n---\nDB:\n  Host: overlay\ntags: [c]\n---\n",
+			))).To(Succeed())
+
+			get := func(key string) interface{} {
+				val, ok := ciMap.Get(key)
+				Expect(ok).To(BeTrue())
+
+				return val
+			}
+			Expect(get("db.host")).To(Equal("overlay"))
+			Expect(get("db.port")).To(Equal(5432))
+			Expect(get("tags")).To(Equal([]interface{}{"c"}))
+			Expect(ciMap.m).To(HaveLen(2))
+		})
+		It("accepts empty input", func() {
+			ciMap = newCiMap()
+			Expect(ciMap.unmarshalYAMLDocuments(nil)).To(Succeed())
+			Expect(ciMap.m).To(BeEmpty())
+		})
+		It("returns errors of later documents", func() {
+			ciMap = newCiMap()
+			Expect(ciMap.unmarshalYAMLDocuments([]byte("a: 1\n---\n[invalid"))).NotTo(Succeed())
+		})
+		It("keeps keys of the same document that only differ in case, underscores or dashes", func() {
+			ciMap = newCiMap()
+			Expect(ciMap.unmarshalYAMLDocuments([]byte("labels:\n  Foo: a\n  foo: b\n"))).To(Succeed())
+			Expect(ciMap.m).To(Equal(map[string]interface{}{"labels": map[string]interface{}{"Foo": "a", "foo": "b"}}))
+
+			// keys of different documents are merged
+			Expect(ciMap.unmarshalYAMLDocuments([]byte("db_host: a\n---\ndbhost: b\n"))).To(Succeed())
+			Expect(ciMap.m).To(Equal(map[string]interface{}{"dbhost": "b"}))
+		})
+	})
 })
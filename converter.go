@@ -0,0 +1,58 @@
+package alligotor
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrConverterResult is returned if a converter registered with Collector.RegisterConverter returns
+// a value that can't be assigned to the target type.
+var ErrConverterResult = errors.New("converter returned a value of the wrong type")
+
+// RegisterConverter registers a function that converts string values from any of the sources to the type of example.
+// This can be used for types that don't implement encoding.TextUnmarshaler, e.g. types from third party packages.
+// Registered converters take precedence over the built-in conversions, also for slice elements.
+// The value returned by fn must be assignable to the type of example.
+// Registering a converter for a type that already has one replaces the previous converter.
+func (c *Collector) RegisterConverter(example interface{}, fn func(string) (interface{}, error)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.converters == nil {
+		c.converters = map[reflect.Type]func(string) (interface{}, error){}
+	}
+
+	c.converters[reflect.TypeOf(example)] = fn
+}
+
+// setFromString sets the field's value with setFromString using the converters registered on the Collector.
+func (c *Collector) setFromString(f *field, value string) error {
+	config := f.Config
+	config.Converters = c.converters
+
+	return setFromString(f.Value, value, config)
+}
+
+// convert sets target with the converter registered for its type.
+// It returns false if there is no converter for the type.
+func convert(target reflect.Value, value string, config parameterConfig) (bool, error) {
+	converter, ok := config.Converters[target.Type()]
+	if !ok {
+		return false, nil
+	}
+
+	converted, err := converter(value)
+	if err != nil {
+		return true, err
+	}
+
+	convertedValue := reflect.ValueOf(converted)
+	if !convertedValue.IsValid() || !convertedValue.Type().AssignableTo(target.Type()) {
+		return true, fmt.Errorf("%w: expected %s, got %T", ErrConverterResult, target.Type(), converted)
+	}
+
+	target.Set(convertedValue)
+
+	return true, nil
+}
@@ -0,0 +1,81 @@
+package alligotor
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type testColor struct {
+	R, G, B uint8
+}
+
+var _ = Describe("RegisterConverter", func() {
+	var c *Collector
+
+	parseColor := func(s string) (interface{}, error) {
+		var color testColor
+		if _, err := fmt.Sscanf(s, "#%02x%02x%02x", &color.R, &color.G, &color.B); err != nil {
+			return nil, err
+		}
+
+		return color, nil
+	}
+
+	BeforeEach(func() {
+		c = &Collector{Env: EnvConfig{Separator: "_"}}
+	})
+
+	It("uses the converter registered for the type", func() {
+		c.RegisterConverter(testColor{}, parseColor)
+
+		cfg := struct{ Color testColor }{}
+		fields, err := getFieldsConfigsFromValue(reflect.ValueOf(&cfg).Elem())
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(c.readEnv(fields, map[string]string{"COLOR": "#ff8000"})).To(Succeed())
+		Expect(cfg.Color).To(Equal(testColor{R: 255, G: 128}))
+	})
+	It("uses the converter for slice elements", func() {
+		c.RegisterConverter(testColor{}, parseColor)
+
+		cfg := struct{ Colors []testColor }{}
+		fields, err := getFieldsConfigsFromValue(reflect.ValueOf(&cfg).Elem())
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(c.readEnv(fields, map[string]string{"COLORS": "#ff0000,#0000ff"})).To(Succeed())
+		Expect(cfg.Colors).To(Equal([]testColor{{R: 255}, {B: 255}}))
+	})
+	It("takes precedence over built-in conversions", func() {
+		c.RegisterConverter(0, func(s string) (interface{}, error) { return len(s), nil })
+
+		cfg := struct{ Port int }{}
+		fields, err := getFieldsConfigsFromValue(reflect.ValueOf(&cfg).Elem())
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(c.readEnv(fields, map[string]string{"PORT": "abc"})).To(Succeed())
+		Expect(cfg.Port).To(Equal(3))
+	})
+	It("returns the converter's error", func() {
+		errConvert := errors.New("convert error")
+		c.RegisterConverter(testColor{}, func(string) (interface{}, error) { return nil, errConvert })
+
+		cfg := struct{ Color testColor }{}
+		fields, err := getFieldsConfigsFromValue(reflect.ValueOf(&cfg).Elem())
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(c.readEnv(fields, map[string]string{"COLOR": "red"})).To(MatchError(errConvert))
+	})
+	It("returns error if the converter returns the wrong type", func() {
+		c.RegisterConverter(testColor{}, func(s string) (interface{}, error) { return s, nil })
+
+		cfg := struct{ Color testColor }{}
+		fields, err := getFieldsConfigsFromValue(reflect.ValueOf(&cfg).Elem())
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(errors.Is(c.readEnv(fields, map[string]string{"COLOR": "red"}), ErrConverterResult)).To(BeTrue())
+	})
+})
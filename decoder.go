@@ -0,0 +1,77 @@
+package alligotor
+
+import (
+	"bytes"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// utf8BOM is the byte order mark some editors write at the start of UTF-8 files.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF} // nolint: gochecknoglobals // used like a constant
+
+// RegisterDecoder registers a decoder for config files with the given extension (e.g. "toml" or ".toml").
+// Files with a registered extension are decoded with the registered decoder instead of the built-in formats.
+// The decoder must return the file content as a map which can contain nested maps for nested structs.
+// Registering a decoder for an extension that already has one replaces the previous decoder.
+func (c *Collector) RegisterDecoder(ext string, fn func([]byte) (map[string]interface{}, error)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.decoders == nil {
+		c.decoders = map[string]func([]byte) (map[string]interface{}, error){}
+	}
+
+	c.decoders[normalizeExt(ext)] = fn
+}
+
+// decode decodes the file content with the decoder registered for the file's extension
+// and falls back to the built-in formats if there is none. Files with the extension .ini are always parsed as ini,
+// so their errors are returned, the format of all other files is detected from the content.
+// A leading UTF-8 byte order mark is removed and Windows line endings are normalized beforehand,
+// e.g. for files that were edited with Notepad.
+func (c *Collector) decode(filePath string, fileBytes []byte) (*ciMap, error) {
+	fileBytes = bytes.TrimPrefix(fileBytes, utf8BOM)
+	fileBytes = bytes.ReplaceAll(fileBytes, []byte("\r\n"), []byte("\n"))
+
+	ext := fileExt(filePath)
+
+	decoder, ok := c.decoders[ext]
+
+	switch {
+	case ok:
+	case ext == iniExt:
+		decoder = c.decodeINI
+	default:
+		return unmarshal(c.Files.Separator, fileBytes)
+	}
+
+	decoded, err := decoder(fileBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	m := newCiMap(withSeparator(c.Files.Separator))
+	if decoded != nil {
+		m.m = decoded
+	}
+
+	return m, nil
+}
+
+func (c *Collector) decodeINI(fileBytes []byte) (map[string]interface{}, error) {
+	return parseINI(separatorOrDefault(c.Files.Separator), fileBytes)
+}
+
+// fileExt returns the normalized extension of a file path or URL.
+func fileExt(filePath string) string {
+	if u, err := url.Parse(filePath); err == nil && u.Scheme != "" {
+		filePath = u.Path
+	}
+
+	return normalizeExt(path.Ext(filePath))
+}
+
+func normalizeExt(ext string) string {
+	return strings.ToLower(strings.TrimPrefix(ext, "."))
+}
@@ -0,0 +1,65 @@
+package alligotor
+
+import (
+	"errors"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RegisterDecoder", func() {
+	var dir string
+	var c *Collector
+	var cfg struct{ Port string }
+	var fields []*field
+
+	errDecode := errors.New("decode error")
+	lineDecoder := func(data []byte) (map[string]interface{}, error) {
+		keyVal := strings.SplitN(strings.TrimSpace(string(data)), " ", 2)
+		if len(keyVal) != 2 {
+			return nil, errDecode
+		}
+
+		return map[string]interface{}{keyVal[0]: keyVal[1]}, nil
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "tests*")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		c = &Collector{Files: FilesConfig{Locations: []string{dir}, BaseName: "config", Separator: "."}}
+
+		cfg.Port = ""
+		fields, err = getFieldsConfigsFromValue(reflect.ValueOf(&cfg).Elem())
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("uses the decoder registered for the extension", func() {
+		c.RegisterDecoder(".LINE", lineDecoder)
+		Expect(os.WriteFile(path.Join(dir, "config.line"), []byte("port 1234"), 0600)).To(Succeed())
+
+		Expect(c.readFiles(fields)).To(Succeed())
+		Expect(cfg.Port).To(Equal("1234"))
+	})
+	It("prefers registered decoders over the built-in ones", func() {
+		c.RegisterDecoder("json", lineDecoder)
+		Expect(os.WriteFile(path.Join(dir, "config.json"), []byte(`{"port":"1234"}`), 0600)).To(Succeed())
+
+		Expect(c.readFiles(fields)).To(MatchError(errDecode))
+	})
+	It("uses the built-in decoders for other extensions", func() {
+		c.RegisterDecoder("line", lineDecoder)
+		Expect(os.WriteFile(path.Join(dir, "config.json"), []byte(`{"port": "1234"}`), 0600)).To(Succeed())
+
+		Expect(c.readFiles(fields)).To(Succeed())
+		Expect(cfg.Port).To(Equal("1234"))
+	})
+})
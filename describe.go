@@ -0,0 +1,132 @@
+package alligotor
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// ErrStructExpected is returned by Collector.Describe if v is neither a struct nor a pointer to a struct.
+var ErrStructExpected = errors.New("expected a struct or a pointer to a struct")
+
+// FieldInfo describes the names under which a field of the config struct is read from the configuration sources.
+// Field is the path of the field in the config struct with the names of all parent fields joined by ".".
+// FileKeys, EnvNames and Flags contain the names in the order in which they're tried, the names defined in the
+// struct tag (including aliases) come before the generated ones. They're empty if the source is disabled.
+// Flags contains the long flag names, FlagShort the shorthand if one is defined.
+// Type is the type of the field, pointers are dereferenced.
+type FieldInfo struct {
+	Field     string
+	Type      reflect.Type
+	FileKeys  []string
+	EnvNames  []string
+	Flags     []string
+	FlagShort string
+}
+
+// Describe returns a FieldInfo for every field that Get would set for the config struct v, including the fields of
+// optional sections (nil struct pointers). In contrast to Get, v can also be a struct or a nil pointer to a struct,
+// since only its type is used and it's never modified. This can be used to generate documentation or help output.
+func (c *Collector) Describe(v interface{}) ([]FieldInfo, error) {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, ErrStructExpected
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	value := reflect.New(t).Elem()
+	allocateOptionalSections(value)
+
+	fields, err := getFieldsConfigsFromValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.checkDuplicateNames(fields); err != nil {
+		return nil, err
+	}
+
+	generatedEnvNames := c.generatedEnvNames(fields)
+
+	var fieldToFlagNames [][]string
+	if !c.Flags.Disabled {
+		fieldToFlagNames = c.mapFlags(fields, func(*field, string, string, string) {})
+	}
+
+	infos := make([]FieldInfo, 0, len(fields))
+
+	for i, f := range fields {
+		info := FieldInfo{
+			Field: f.FullName("."),
+			// the value can't be used since it's invalid for nil pointers to non-struct types
+			Type: f.valueType(),
+		}
+
+		if !c.Files.Disabled {
+			info.FileKeys = c.describeFileKeys(f)
+		}
+
+		if !c.Env.Disabled {
+			info.EnvNames = c.describeEnvNames(f, generatedEnvNames[i])
+		}
+
+		if !c.Flags.Disabled {
+			info.Flags = fieldToFlagNames[i]
+			info.FlagShort = f.Config.Flag.ShortName
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos, nil
+}
+
+func (c *Collector) describeFileKeys(f *field) []string {
+	var keys []string
+
+	for _, key := range append(append([]string{f.Config.DefaultFileField}, f.Config.FileAliases...), c.fileFieldName(f)) {
+		if c.Files.UseNamespace && c.Namespace != "" && key != "" {
+			key = c.Namespace + c.Files.Separator + key
+		}
+
+		keys = append(keys, key)
+	}
+
+	return distinctNames(keys)
+}
+
+func (c *Collector) describeEnvNames(f *field, generatedName string) []string {
+	var names []string
+
+	for _, name := range append([]string{f.Config.DefaultEnvName}, f.Config.EnvAliases...) {
+		if name != "" {
+			names = append(names, strings.ToUpper(c.prefixExplicitEnvName(name)))
+		}
+	}
+
+	return distinctNames(append(names, generatedName))
+}
+
+// distinctNames returns the non-empty names without duplicates.
+func distinctNames(names []string) []string {
+	var distinct []string
+
+	seen := map[string]bool{}
+
+	for _, name := range names {
+		if name == "" || seen[name] {
+			continue
+		}
+
+		seen[name] = true
+		distinct = append(distinct, name)
+	}
+
+	return distinct
+}
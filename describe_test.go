@@ -0,0 +1,96 @@
+package alligotor
+
+import (
+	"reflect"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Describe", func() {
+	type config struct {
+		Port    int `config:"env=PORT|LISTEN_PORT,flag=p listen,file=listen"`
+		Timeout time.Duration
+		DB      *struct {
+			Host string
+		}
+	}
+
+	var c *Collector
+
+	BeforeEach(func() {
+		c = &Collector{
+			Files: FilesConfig{Separator: "."},
+			Env:   EnvConfig{Prefix: "APP", Separator: "_"},
+			Flags: FlagsConfig{Separator: "-"},
+		}
+	})
+
+	It("describes the names of all fields", func() {
+		infos, err := c.Describe(config{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(infos).To(Equal([]FieldInfo{
+			{
+				Field:     "Port",
+				Type:      reflect.TypeOf(0),
+				FileKeys:  []string{"listen", "Port"},
+				EnvNames:  []string{"PORT", "LISTEN_PORT", "APP_PORT"},
+				Flags:     []string{"listen", "port"},
+				FlagShort: "p",
+			},
+			{
+				Field:    "Timeout",
+				Type:     reflect.TypeOf(time.Duration(0)),
+				FileKeys: []string{"Timeout"},
+				EnvNames: []string{"APP_TIMEOUT"},
+				Flags:    []string{"timeout"},
+			},
+			{
+				Field:    "DB",
+				Type:     reflect.TypeOf(struct{ Host string }{}),
+				FileKeys: []string{"DB"},
+				EnvNames: []string{"APP_DB"},
+				Flags:    []string{"db"},
+			},
+			{
+				Field:    "DB.Host",
+				Type:     reflect.TypeOf(""),
+				FileKeys: []string{"DB.Host"},
+				EnvNames: []string{"APP_DB_HOST"},
+				Flags:    []string{"db-host"},
+			},
+		}))
+	})
+	It("accepts nil pointers and leaves out disabled sources", func() {
+		c.Env.Disabled = true
+		c.Flags.Disabled = true
+		c.Namespace = "app"
+		c.Files.UseNamespace = true
+
+		infos, err := c.Describe((*config)(nil))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(infos).To(HaveLen(4))
+		Expect(infos[0].FileKeys).To(Equal([]string{"app.listen", "app.Port"}))
+		Expect(infos[0].EnvNames).To(BeNil())
+		Expect(infos[0].Flags).To(BeNil())
+	})
+	It("describes nil pointers to non-struct types", func() {
+		infos, err := c.Describe(struct {
+			P     *int
+			Names *[]string
+		}{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(infos).To(HaveLen(2))
+		Expect(infos[0].Type).To(Equal(reflect.TypeOf(0)))
+		Expect(infos[0].Flags).To(Equal([]string{"p"}))
+		Expect(infos[1].Type).To(Equal(reflect.TypeOf([]string{})))
+	})
+	It("rejects values that are no structs", func() {
+		_, err := c.Describe(1)
+		Expect(err).To(MatchError(ErrStructExpected))
+
+		_, err = c.Describe(nil)
+		Expect(err).To(MatchError(ErrStructExpected))
+	})
+})
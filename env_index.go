@@ -0,0 +1,122 @@
+package alligotor
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrIndexOutOfRange is returned if the index of an indexed environment variable is too large.
+var ErrIndexOutOfRange = errors.New("index out of range")
+
+// maxEnvIndex limits the indices of indexed environment variables to avoid huge allocations because of a typo.
+const maxEnvIndex = 1 << 16
+
+// readEnvIndices sets the elements of a slice field from indexed environment variables,
+// e.g. MYAPP_SERVERS_0_HOST sets the Host field of the first element of Servers and MYAPP_TAGS_1 the second tag.
+// The slice is grown if needed, elements without any environment variable keep their values.
+func (c *Collector) readEnvIndices(f *field, vars map[string]string) error {
+	if c.Env.Separator == "" || !f.Value.IsValid() || !isRepeatable(f.Value.Type()) {
+		return nil
+	}
+
+	indices, err := c.envIndices(f, vars)
+	if err != nil || len(indices) == 0 {
+		return err
+	}
+
+	// always copy the slice to not modify the backing array of the original value
+	length := f.Value.Len()
+	if maxIndex := indices[len(indices)-1]; maxIndex >= length {
+		length = maxIndex + 1
+	}
+
+	slice := reflect.MakeSlice(f.Value.Type(), length, length)
+	reflect.Copy(slice, f.Value)
+	f.Value.Set(slice)
+
+	base := append(append([]string{}, f.Base...), f.Name)
+
+	for _, index := range indices {
+		elemFields, err := elementFields(f, base, index)
+		if err != nil {
+			return err
+		}
+
+		if err := c.readEnv(elemFields, vars); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// envIndices returns the sorted indices of all environment variables that start with the field's env name
+// followed by the separator and an index.
+func (c *Collector) envIndices(f *field, vars map[string]string) ([]int, error) {
+	prefix := c.distinctEnvName(f) + strings.ToUpper(c.Env.Separator)
+	found := map[int]bool{}
+
+	for envName := range vars {
+		if !strings.HasPrefix(envName, prefix) {
+			continue
+		}
+
+		indexStr := strings.SplitN(strings.TrimPrefix(envName, prefix), strings.ToUpper(c.Env.Separator), 2)[0]
+
+		index, err := strconv.Atoi(indexStr)
+		// only plain indices are accepted, so 01 or +1 don't result in the same index as 1
+		if err != nil || index < 0 || strconv.Itoa(index) != indexStr {
+			continue
+		}
+
+		if index >= maxEnvIndex {
+			return nil, fmt.Errorf("%w: %s", ErrIndexOutOfRange, envName)
+		}
+
+		found[index] = true
+	}
+
+	indices := make([]int, 0, len(found))
+	for index := range found {
+		indices = append(indices, index)
+	}
+
+	sort.Ints(indices)
+
+	return indices, nil
+}
+
+// elementFields returns the field for the element at index of the slice field and the fields of the element
+// if it is a struct. Names defined in the struct tags are not used for elements since they would apply to all elements.
+func elementFields(f *field, base []string, index int) ([]*field, error) {
+	elem := f.Value.Index(index)
+	if elem.Kind() == reflect.Ptr && elem.IsNil() && elem.Type().Elem().Kind() == reflect.Struct {
+		elem.Set(reflect.New(elem.Type().Elem()))
+	}
+
+	elem = reflect.Indirect(elem)
+
+	elemConfig := f.Config
+	elemConfig.DefaultEnvName = ""
+
+	fields := []*field{{Base: base, Name: strconv.Itoa(index), Value: elem, Config: elemConfig}}
+
+	if elem.Kind() == reflect.Struct && !isUnmarshaler(elem.Type()) {
+		subFields, err := getFieldsConfigsFromValue(elem, append(append([]string{}, base...), strconv.Itoa(index))...)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, subField := range subFields {
+			subField.Config.DefaultEnvName = ""
+		}
+
+		fields = append(fields, subFields...)
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,82 @@
+package alligotor
+
+import (
+	"errors"
+	"reflect"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("readEnvIndices", func() {
+	type server struct {
+		Host string `config:"env=HOST"`
+		Port int
+	}
+
+	var c *Collector
+
+	BeforeEach(func() {
+		c = &Collector{Env: EnvConfig{Prefix: "MYAPP", Separator: "_"}}
+	})
+
+	It("sets struct elements from indexed env vars", func() {
+		cfg := struct{ Servers []server }{}
+		fields, err := getFieldsConfigsFromValue(reflect.ValueOf(&cfg).Elem())
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(c.readEnv(fields, map[string]string{
+			"MYAPP_SERVERS_0_HOST": "a",
+			"MYAPP_SERVERS_2_HOST": "c",
+			"MYAPP_SERVERS_2_PORT": "3",
+			"HOST":                 "ignored",
+		})).To(Succeed())
+		Expect(cfg.Servers).To(Equal([]server{{Host: "a"}, {}, {Host: "c", Port: 3}}))
+	})
+	It("sets pointer and scalar elements", func() {
+		cfg := struct {
+			Servers []*server
+			Tags    []string
+		}{Tags: []string{"a", "b"}}
+		defaults := cfg.Tags
+		fields, err := getFieldsConfigsFromValue(reflect.ValueOf(&cfg).Elem())
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(c.readEnv(fields, map[string]string{
+			"MYAPP_SERVERS_1_PORT": "2",
+			"MYAPP_TAGS_1":         "c",
+			"MYAPP_TAGS_01":        "ignored",
+		})).To(Succeed())
+		Expect(cfg.Servers).To(Equal([]*server{nil, {Port: 2}}))
+		Expect(cfg.Tags).To(Equal([]string{"a", "c"}))
+		Expect(defaults).To(Equal([]string{"a", "b"}))
+	})
+	It("applies indexed env vars after the whole value", func() {
+		cfg := struct{ Tags []string }{}
+		fields, err := getFieldsConfigsFromValue(reflect.ValueOf(&cfg).Elem())
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(c.readEnv(fields, map[string]string{"MYAPP_TAGS": "a,b", "MYAPP_TAGS_0": "c"})).To(Succeed())
+		Expect(cfg.Tags).To(Equal([]string{"c", "b"}))
+	})
+	It("returns error for huge indices", func() {
+		cfg := struct{ Tags []string }{}
+		fields, err := getFieldsConfigsFromValue(reflect.ValueOf(&cfg).Elem())
+		Expect(err).ShouldNot(HaveOccurred())
+
+		err = c.readEnv(fields, map[string]string{"MYAPP_TAGS_100000000": "a"})
+		Expect(errors.Is(err, ErrIndexOutOfRange)).To(BeTrue())
+	})
+	It("ignores nil pointers to non-struct types", func() {
+		cfg := struct {
+			P    *int
+			Tags *[]string
+		}{}
+		fields, err := getFieldsConfigsFromValue(reflect.ValueOf(&cfg).Elem())
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(c.readEnv(fields, map[string]string{"MYAPP_TAGS_0": "a"})).To(Succeed())
+		Expect(cfg.Tags).To(BeNil())
+		Expect(c.readEnv(fields, map[string]string{"MYAPP_P": "1"})).To(MatchError(ErrCantSet))
+	})
+})
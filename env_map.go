@@ -0,0 +1,70 @@
+package alligotor
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// readEnvMapEntries sets the entries of a map field from environment variables that start with the field's
+// env name followed by the separator, e.g. MYAPP_LABELS_TEAM=core sets the entry team of Labels to core.
+// The keys are the lower cased rest of the variable names. Existing entries are kept and the entries from
+// the environment variables take precedence, also over the ones of the comma-joined MYAPP_LABELS.
+func (c *Collector) readEnvMapEntries(f *field, vars map[string]string) error {
+	if c.Env.Separator == "" || f.Value.Kind() != reflect.Map || isUnmarshaler(f.Value.Type()) {
+		return nil
+	}
+
+	fieldEnvName := c.distinctEnvName(f)
+	prefix := fieldEnvName + strings.ToUpper(c.Env.Separator)
+	fileEnvName := fieldEnvName + strings.ToUpper(c.Env.FileSuffix)
+
+	var envNames []string
+
+	for envName := range vars {
+		if !strings.HasPrefix(envName, prefix) || envName == prefix || c.Env.FileSuffix != "" && envName == fileEnvName {
+			continue
+		}
+
+		envNames = append(envNames, envName)
+	}
+
+	if len(envNames) == 0 {
+		return nil
+	}
+
+	sort.Strings(envNames)
+
+	config := f.Config
+	config.Converters = c.converters
+
+	// always copy the map to not modify the original
+	m := reflect.MakeMapWithSize(f.Value.Type(), f.Value.Len()+len(envNames))
+	for iter := f.Value.MapRange(); iter.Next(); {
+		m.SetMapIndex(iter.Key(), iter.Value())
+	}
+
+	for _, envName := range envNames {
+		envVal := vars[envName]
+		if c.Env.TrimSpace {
+			envVal = strings.TrimSpace(envVal)
+		}
+
+		key := reflect.New(f.Value.Type().Key()).Elem()
+		if err := setFromString(key, strings.ToLower(strings.TrimPrefix(envName, prefix)), config); err != nil {
+			return err
+		}
+
+		val := reflect.New(f.Value.Type().Elem()).Elem()
+		if err := setFromString(val, envVal, config); err != nil {
+			return err
+		}
+
+		m.SetMapIndex(key, val)
+		f.Value.Set(m)
+
+		c.record(f, SourceEnv, envName, envVal)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package alligotor
+
+import (
+	"os"
+	"path"
+	"reflect"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("readEnvMapEntries", func() {
+	var c *Collector
+
+	BeforeEach(func() {
+		c = &Collector{Env: EnvConfig{Prefix: "MYAPP", Separator: "_", FileSuffix: "_FILE"}}
+	})
+
+	It("sets map entries from env vars with the field's name as prefix", func() {
+		original := map[string]string{"env": "dev", "region": "eu"}
+		cfg := struct{ Labels map[string]string }{Labels: original}
+		fields, err := getFieldsConfigsFromValue(reflect.ValueOf(&cfg).Elem())
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(c.readEnv(fields, map[string]string{
+			"MYAPP_LABELS":          "owner=me",
+			"MYAPP_LABELS_ENV":      "prod",
+			"MYAPP_LABELS_TEAM":     "core",
+			"MYAPP_LABELS_APP_NAME": "api",
+			"LABELS_OTHER":          "ignored",
+		})).To(Succeed())
+		Expect(cfg.Labels).To(Equal(map[string]string{"owner": "me", "env": "prod", "team": "core", "app_name": "api"}))
+		Expect(original).To(Equal(map[string]string{"env": "dev", "region": "eu"}))
+	})
+	It("converts the keys and values", func() {
+		cfg := struct{ Limits map[string]int }{}
+		fields, err := getFieldsConfigsFromValue(reflect.ValueOf(&cfg).Elem())
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(c.readEnv(fields, map[string]string{"MYAPP_LIMITS_CPU": "2"})).To(Succeed())
+		Expect(cfg.Limits).To(Equal(map[string]int{"cpu": 2}))
+
+		Expect(c.readEnv(fields, map[string]string{"MYAPP_LIMITS_CPU": "two"})).NotTo(Succeed())
+	})
+	It("ignores the file env var of the field", func() {
+		dir, err := os.MkdirTemp("", "tests*")
+		Expect(err).ShouldNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		Expect(os.WriteFile(path.Join(dir, "labels"), []byte("env=prod"), 0600)).To(Succeed())
+
+		cfg := struct{ Labels map[string]string }{}
+		fields, err := getFieldsConfigsFromValue(reflect.ValueOf(&cfg).Elem())
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(c.readEnv(fields, map[string]string{
+			"MYAPP_LABELS_FILE":      path.Join(dir, "labels"),
+			"MYAPP_LABELS_FILE_NAME": "x",
+		})).To(Succeed())
+		Expect(cfg.Labels).To(Equal(map[string]string{"env": "prod", "file_name": "x"}))
+	})
+})
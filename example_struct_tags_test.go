@@ -2,7 +2,6 @@ package alligotor_test
 
 import (
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path"
 
@@ -33,9 +32,11 @@ type StructTagConfig struct {
 // Im this example type string is used as type for loglevel, but zapcore.Level and logrus.Level are also
 // supported out of the box. It's just not used here to mimize the package's dependencies.
 //
-// Also flags short and long name can be set in the struct tag.
+// Also flags short and long name can be set in the struct tag. Names with a single character are used as short names
+// and all others as long names. This can be made explicit with the short: and long: prefixes, e.g. `config:"flag=long:x"`
+// defines the single letter long name --x.
 func Example_structTags() {
-	dir, _ := ioutil.TempDir("", "testing")
+	dir, _ := os.MkdirTemp("", "testing")
 	defer os.RemoveAll(dir)
 
 	jsonBytes := []byte(`{
@@ -49,7 +50,7 @@ func Example_structTags() {
 }`)
 
 	filePath := path.Join(dir, "example_config.json")
-	_ = ioutil.WriteFile(filePath, jsonBytes, 0600)
+	_ = os.WriteFile(filePath, jsonBytes, 0600)
 
 	os.Args = []string{"cmdName", "-p", "2345"}
 
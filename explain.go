@@ -0,0 +1,136 @@
+package alligotor
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// SourceKind describes the kind of configuration source a value was read from.
+type SourceKind string
+
+const (
+	SourceFile SourceKind = "file"
+	SourceEnv  SourceKind = "env"
+	SourceFlag SourceKind = "flag"
+)
+
+// SourceHit describes a value that was read for a field from one of the configuration sources.
+// Field is the path of the field in the config struct with the names of all parent fields joined by ".".
+// Source is the kind of source and Key the file key, environment variable or flag name the value was read from.
+// Raw contains the value as found in the source and Value the value the field was resolved to.
+type SourceHit struct {
+	Field  string
+	Source SourceKind
+	Key    string
+	Raw    string
+	Value  interface{}
+}
+
+// Explain reports what Get would set from each source without modifying v.
+// It expects a pointer to the config struct just like Get and returns a SourceHit for every value that
+// is found in any of the enabled sources, in the order in which they are applied.
+// This includes values that are overwritten later on by a source with a higher priority,
+// so the last hit for a field is the value Get would end up with.
+func (c *Collector) Explain(v interface{}) ([]SourceHit, error) {
+	value := reflect.ValueOf(v)
+	if err := checkPointer(value); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var hits []SourceHit
+
+	c.onSet = func(hit SourceHit) {
+		hits = append(hits, hit)
+	}
+	defer func() { c.onSet = nil }()
+
+	target := reflect.New(value.Elem().Type())
+	target.Elem().Set(deepCopy(value.Elem()))
+
+	if err := c.get(target.Interface(), true); err != nil {
+		return nil, err
+	}
+
+	return hits, nil
+}
+
+// record marks the field as set from a source and reports the value if a hook is registered.
+func (c *Collector) record(f *field, source SourceKind, key string, raw interface{}) {
+	if c.touched != nil {
+		c.touched[f.FullName(".")] = true
+	}
+
+	if source == SourceEnv && c.consumedEnv != nil {
+		c.consumedEnv[key] = true
+	}
+
+	c.log(LogLevelDebug, "field set", "field", f.FullName("."), "source", source, "key", key, "raw", raw)
+
+	if c.onSet == nil {
+		return
+	}
+
+	c.onSet(SourceHit{
+		Field:  f.FullName("."),
+		Source: source,
+		Key:    key,
+		Raw:    fmt.Sprint(raw),
+		Value:  f.Value.Interface(),
+	})
+}
+
+// deepCopy returns a copy of v in which pointers, maps and slices are copied recursively as well.
+// Get sets the values behind pointers in place and decodes file values into existing maps and slices,
+// so these need to be copied to not modify the original.
+func deepCopy(v reflect.Value) reflect.Value {
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+
+	switch v.Kind() { // nolint: exhaustive // only containers need special handling
+	case reflect.Ptr:
+		if v.IsNil() {
+			break
+		}
+
+		p := reflect.New(v.Elem().Type())
+		p.Elem().Set(deepCopy(v.Elem()))
+		c.Set(p)
+	case reflect.Map:
+		if v.IsNil() {
+			break
+		}
+
+		m := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			m.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+
+		c.Set(m)
+	case reflect.Slice:
+		if v.IsNil() {
+			break
+		}
+
+		s := reflect.MakeSlice(v.Type(), v.Len(), v.Cap())
+		for i := 0; i < v.Len(); i++ {
+			s.Index(i).Set(deepCopy(v.Index(i)))
+		}
+
+		c.Set(s)
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+	}
+
+	return c
+}
@@ -0,0 +1,91 @@
+package alligotor
+
+import (
+	"os"
+	"path"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Explain", func() {
+	var dir string
+	var c *Collector
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "tests*")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		c = &Collector{
+			Files: FilesConfig{
+				Locations: []string{dir},
+				BaseName:  "config",
+				Separator: ".",
+			},
+			Env: EnvConfig{
+				Prefix:    "EXPLAIN",
+				Separator: "_",
+			},
+			Flags: FlagsConfig{
+				Separator: "-",
+				Args:      []string{"-p", "3"},
+			},
+		}
+	})
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("returns error if v is not a pointer", func() {
+		_, err := c.Explain(struct{}{})
+		Expect(err).To(Equal(ErrPointerExpected))
+	})
+	It("reports all hits in order without modifying v", func() {
+		Expect(os.WriteFile(path.Join(dir, "config.json"), []byte(`{"api": {"port": 1}}`), 0600)).To(Succeed())
+		Expect(os.Setenv("EXPLAIN_API_PORT", "2")).To(Succeed())
+		defer os.Unsetenv("EXPLAIN_API_PORT")
+
+		type apiConfig struct {
+			Port int `config:"flag=p"`
+		}
+
+		cfg := struct {
+			API *apiConfig
+		}{API: &apiConfig{Port: 42}}
+
+		hits, err := c.Explain(&cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cfg.API.Port).To(Equal(42))
+
+		Expect(hits).To(Equal([]SourceHit{
+			{Field: "API", Source: SourceFile, Key: "API", Raw: "map[port:1]", Value: apiConfig{Port: 1}},
+			{Field: "API.Port", Source: SourceFile, Key: "API.Port", Raw: "1", Value: 1},
+			{Field: "API.Port", Source: SourceEnv, Key: "EXPLAIN_API_PORT", Raw: "2", Value: 2},
+			{Field: "API.Port", Source: SourceFlag, Key: "api-port", Raw: "3", Value: 3},
+		}))
+	})
+	It("doesn't modify maps, slices and pointers of v", func() {
+		Expect(os.WriteFile(path.Join(dir, "config.json"),
+			[]byte(`{"server": {"labels": {"a": 2}, "tags": ["y"], "timeout": 5}}`), 0600)).To(Succeed())
+		c.Flags.Disabled = true
+
+		timeout := 1
+		cfg := struct {
+			Server struct {
+				Labels  map[string]int
+				Tags    []string
+				Timeout *int
+			}
+		}{}
+		cfg.Server.Labels = map[string]int{"d": 1}
+		cfg.Server.Tags = []string{"z"}
+		cfg.Server.Timeout = &timeout
+
+		_, err := c.Explain(&cfg)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cfg.Server.Labels).To(Equal(map[string]int{"d": 1}))
+		Expect(cfg.Server.Tags).To(Equal([]string{"z"}))
+		Expect(timeout).To(Equal(1))
+	})
+})
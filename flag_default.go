@@ -0,0 +1,115 @@
+package alligotor
+
+import (
+	"encoding"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// flagDefault returns the current value of the field in the format that is accepted by the flag,
+// so it can be shown as default value in the usage. Zero values result in an empty string to not show them.
+func flagDefault(f *field) string {
+	if !f.Value.IsValid() || !f.Value.CanInterface() || f.Value.IsZero() {
+		return ""
+	}
+
+	return formatValue(f.Value, f.Config)
+}
+
+// flagDefaults returns the formatted elements of a repeatable field, see flagDefault.
+func flagDefaults(f *field) []string {
+	if !f.Value.IsValid() || !f.Value.CanInterface() || f.Value.Len() == 0 {
+		return nil
+	}
+
+	elements := make([]string, 0, f.Value.Len())
+	for i := 0; i < f.Value.Len(); i++ {
+		elements = append(elements, formatValue(f.Value.Index(i), f.Config))
+	}
+
+	return elements
+}
+
+// formatValue returns the value as string in the format that is parsed by setFromString.
+func formatValue(value reflect.Value, config parameterConfig) string {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return ""
+		}
+
+		value = value.Elem()
+	}
+
+	switch typedValue := value.Interface().(type) {
+	case time.Duration:
+		return typedValue.String()
+	case time.Time:
+		return formatTime(typedValue, config.TimeFormat)
+	case []byte:
+		encoding := config.Base64Encoding
+		if encoding == nil {
+			encoding = base64.StdEncoding
+		}
+
+		return encoding.EncodeToString(typedValue)
+	case encoding.TextMarshaler:
+		return marshalText(typedValue)
+	}
+
+	if value.CanAddr() {
+		if marshaler, ok := value.Addr().Interface().(encoding.TextMarshaler); ok {
+			return marshalText(marshaler)
+		}
+	}
+
+	switch value.Kind() { // nolint: exhaustive // all other kinds are formatted with fmt
+	case reflect.Slice, reflect.Array:
+		elements := make([]string, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			elements = append(elements, formatValue(value.Index(i), config))
+		}
+
+		return strings.Join(elements, config.listSeparator())
+	case reflect.Map:
+		entries := make([]string, 0, value.Len())
+		for _, key := range value.MapKeys() {
+			entries = append(entries,
+				formatValue(key, config)+config.keyValueSeparator()+formatValue(value.MapIndex(key), config))
+		}
+
+		sort.Strings(entries)
+
+		return strings.Join(entries, config.listSeparator())
+	case reflect.Struct:
+		return ""
+	default:
+		return fmt.Sprint(value.Interface())
+	}
+}
+
+func formatTime(t time.Time, timeFormat string) string {
+	switch timeFormat {
+	case "":
+		return t.Format(time.RFC3339)
+	case timeFormatUnix:
+		return strconv.FormatInt(t.Unix(), 10)
+	case timeFormatUnixMilli:
+		return strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10)
+	default:
+		return t.Format(timeFormat)
+	}
+}
+
+func marshalText(marshaler encoding.TextMarshaler) string {
+	text, err := marshaler.MarshalText()
+	if err != nil {
+		return ""
+	}
+
+	return string(text)
+}
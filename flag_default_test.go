@@ -0,0 +1,57 @@
+package alligotor
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("flag defaults", func() {
+	var c *Collector
+
+	BeforeEach(func() {
+		c = &Collector{Flags: FlagsConfig{Separator: "-"}}
+	})
+
+	It("shows the current values as defaults in the usage", func() {
+		cfg := struct {
+			Workers int
+			Timeout time.Duration
+			Debug   bool
+			Hosts   []string
+			Labels  map[string]string
+			Name    string
+		}{
+			Workers: 8080,
+			Timeout: 5 * time.Second,
+			Debug:   true,
+			Hosts:   []string{"a", "b"},
+			Labels:  map[string]string{"b": "2", "a": "1"},
+		}
+
+		var buf bytes.Buffer
+		Expect(c.PrintDefaults(&cfg, &buf)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring(`(default "8080")`))
+		Expect(buf.String()).To(ContainSubstring(`(default "5s")`))
+		Expect(buf.String()).To(MatchRegexp(`--debug\s+Debug \(env DEBUG\) \(default true\)`))
+		Expect(buf.String()).To(ContainSubstring("(default [a,b])"))
+		Expect(buf.String()).To(ContainSubstring(`(default "a=1,b=2")`))
+		Expect(buf.String()).To(MatchRegexp(`--name string\s+Name \(env NAME\)\n`))
+	})
+	It("doesn't change the parsing", func() {
+		cfg := struct {
+			Workers int
+			Debug   bool
+			Hosts   []string
+			Name    string
+		}{Workers: 8080, Debug: true, Hosts: []string{"a"}, Name: "default"}
+
+		Expect(c.Get(&cfg, WithoutFiles(), WithoutEnv(), WithArgs("--hosts", "b", "--hosts", "c", "--no-debug"))).To(Succeed())
+		Expect(cfg.Workers).To(Equal(8080))
+		Expect(cfg.Debug).To(BeFalse())
+		Expect(cfg.Hosts).To(Equal([]string{"b", "c"}))
+		Expect(cfg.Name).To(Equal("default"))
+	})
+})
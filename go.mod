@@ -1,8 +1,9 @@
 module github.com/brumhard/alligotor
 
-go 1.15
+go 1.16
 
 require (
+	github.com/fsnotify/fsnotify v1.4.9
 	github.com/mitchellh/mapstructure v1.3.3
 	github.com/onsi/ginkgo v1.14.2
 	github.com/onsi/gomega v1.10.3
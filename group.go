@@ -0,0 +1,78 @@
+package alligotor
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrGroupViolated is returned if the fields of a group defined with the group key in the struct tag
+// don't satisfy the GroupRule of the group.
+var ErrGroupViolated = errors.New("group constraint violated")
+
+// GroupRule defines how many fields of a group need to be set, see Collector.Groups.
+type GroupRule int
+
+const (
+	// GroupExactlyOne requires exactly one field of the group to be set. It's the default for all groups.
+	GroupExactlyOne GroupRule = iota
+	// GroupAtLeastOne requires one or more fields of the group to be set.
+	GroupAtLeastOne
+)
+
+// checkGroups checks that the fields with the same group in the struct tags satisfy the rule of that group.
+// A field counts as set if it's not the zero value of its type, empty slices and maps count as not set.
+func checkGroups(fields []*field, rules map[string]GroupRule) error {
+	groups := make(map[string][]*field)
+
+	for _, f := range fields {
+		if f.Config.Group != "" {
+			groups[f.Config.Group] = append(groups[f.Config.Group], f)
+		}
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+
+	// sorted for deterministic errors if multiple groups are violated
+	sort.Strings(names)
+
+	for _, name := range names {
+		var fieldNames, setNames []string
+
+		for _, f := range groups[name] {
+			fieldNames = append(fieldNames, f.FullName("."))
+
+			if isSet(f.Value) {
+				setNames = append(setNames, f.FullName("."))
+			}
+		}
+
+		switch {
+		case len(setNames) == 0:
+			return fmt.Errorf(
+				"%w: one of %s needs to be set for group %s", ErrGroupViolated, strings.Join(fieldNames, ", "), name,
+			)
+		case len(setNames) > 1 && rules[name] == GroupExactlyOne:
+			return fmt.Errorf(
+				"%w: only one of %s can be set for group %s, got %s",
+				ErrGroupViolated, strings.Join(fieldNames, ", "), name, strings.Join(setNames, ", "),
+			)
+		}
+	}
+
+	return nil
+}
+
+func isSet(value reflect.Value) bool {
+	switch value.Kind() { // nolint: exhaustive // all other kinds are compared to their zero value
+	case reflect.Slice, reflect.Map:
+		return value.Len() > 0
+	default:
+		return !value.IsZero()
+	}
+}
@@ -0,0 +1,67 @@
+package alligotor
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("group", func() {
+	type config struct {
+		ConfigFile string   `config:"group=source"`
+		ConfigURL  string   `config:"group=source"`
+		Tags       []string `config:"group=source"`
+	}
+
+	var c *Collector
+
+	BeforeEach(func() {
+		c = &Collector{
+			Files: FilesConfig{Disabled: true},
+			Env:   EnvConfig{Disabled: true},
+			Flags: FlagsConfig{Separator: "-"},
+		}
+	})
+
+	It("accepts exactly one field of the group by default", func() {
+		cfg := config{}
+		c.Flags.Args = []string{"--configurl", "http://example.com"}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+	})
+	It("returns an error if no field of the group is set", func() {
+		cfg := config{}
+
+		err := c.Get(&cfg)
+		Expect(err).To(MatchError(ErrGroupViolated))
+		Expect(err.Error()).To(ContainSubstring("ConfigFile, ConfigURL, Tags"))
+	})
+	It("counts defaults as set", func() {
+		cfg := config{ConfigFile: "config.yaml"}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+	})
+	It("counts empty slices as not set", func() {
+		cfg := config{ConfigFile: "config.yaml", Tags: []string{}}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+	})
+	It("returns an error if multiple fields of the group are set", func() {
+		cfg := config{}
+		c.Flags.Args = []string{"--configurl", "http://example.com", "--configfile", "config.yaml"}
+
+		err := c.Get(&cfg)
+		Expect(err).To(MatchError(ErrGroupViolated))
+		Expect(err.Error()).To(ContainSubstring("got ConfigFile, ConfigURL"))
+	})
+	It("accepts multiple fields with GroupAtLeastOne", func() {
+		cfg := config{}
+		c.Groups = map[string]GroupRule{"source": GroupAtLeastOne}
+		c.Flags.Args = []string{"--configurl", "http://example.com", "--configfile", "config.yaml"}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+
+		c.Flags.Args = nil
+		cfg = config{}
+		Expect(c.Get(&cfg)).To(MatchError(ErrGroupViolated))
+	})
+})
@@ -0,0 +1,63 @@
+package alligotor
+
+import (
+	"io"
+	"reflect"
+)
+
+// PrintDefaults writes the usage of the flags that Get registers for the config struct v to w,
+// using the format of pflag's FlagSet.PrintDefaults, e.g. to print them for -h/--help.
+// Optional sections (nil struct pointers) are included but stay nil.
+// The flags of structs that can't be set from a string themselves are not listed, only the ones of their fields.
+func (c *Collector) PrintDefaults(v interface{}, w io.Writer) error {
+	value := reflect.ValueOf(v)
+	if err := checkStructPointer(value); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.touched = map[string]bool{}
+
+	sections := allocateOptionalSections(reflect.Indirect(value))
+	defer c.resetUntouchedSections(sections)
+
+	fields, err := getFieldsConfigsFromValue(reflect.Indirect(value))
+	if err != nil {
+		return err
+	}
+
+	if err := c.checkDuplicateNames(fields); err != nil {
+		return err
+	}
+
+	flagSet, fieldToFlagNames := c.newPFlagSet(fields)
+
+	for i, f := range fields {
+		if !c.onlyGroupsFields(f) {
+			continue
+		}
+
+		for _, name := range fieldToFlagNames[i] {
+			flagSet.Lookup(name).Hidden = true
+		}
+	}
+
+	flagSet.SetOutput(w)
+	flagSet.PrintDefaults()
+
+	return nil
+}
+
+// onlyGroupsFields returns true for struct fields that only group their child fields,
+// i.e. they neither implement an unmarshaler nor are decoded from JSON or with a converter.
+func (c *Collector) onlyGroupsFields(f *field) bool {
+	if f.Value.Kind() != reflect.Struct || isUnmarshaler(f.Value.Type()) || f.Config.JSON {
+		return false
+	}
+
+	_, ok := c.converters[f.Value.Type()]
+
+	return !ok
+}
@@ -0,0 +1,68 @@
+package alligotor
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PrintDefaults", func() {
+	var c *Collector
+
+	BeforeEach(func() {
+		c = &Collector{Flags: FlagsConfig{Separator: "-"}}
+	})
+
+	It("prints the generated flags", func() {
+		cfg := struct {
+			Port int `config:"flag=p"`
+			API  struct {
+				Enabled bool
+			}
+			Optional *struct {
+				Name string
+			}
+		}{Port: 8080}
+
+		var buf bytes.Buffer
+		Expect(c.PrintDefaults(&cfg, &buf)).To(Succeed())
+		Expect(buf.String()).To(Equal("" +
+			"      --api-enabled            API.Enabled (env APIENABLED)\n" +
+			"      --no-api-enabled         negates --api-enabled\n" +
+			"      --optional-name string   Optional.Name (env OPTIONALNAME)\n" +
+			"  -p, --port string            Port (env PORT) (default \"8080\")\n",
+		))
+		Expect(cfg.Optional).To(BeNil())
+
+		c.Env.Disabled = true
+		buf.Reset()
+		Expect(c.PrintDefaults(&struct{ Port int }{}, &buf)).To(Succeed())
+		Expect(buf.String()).To(Equal("      --port string   Port\n"))
+	})
+	It("prints the flags of structs that can be set from a string", func() {
+		cfg := struct {
+			API struct {
+				Enabled bool
+			} `config:"json"`
+		}{}
+
+		var buf bytes.Buffer
+		Expect(c.PrintDefaults(&cfg, &buf)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring("--api string"))
+	})
+	It("doesn't print flags for the fields of structs that implement an unmarshaler", func() {
+		cfg := struct {
+			Value testType
+		}{}
+
+		var buf bytes.Buffer
+		Expect(c.PrintDefaults(&cfg, &buf)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring("--value"))
+		Expect(buf.String()).NotTo(ContainSubstring("--value-s"))
+	})
+	It("returns error if v is not a pointer", func() {
+		var buf bytes.Buffer
+		Expect(c.PrintDefaults(struct{}{}, &buf)).To(Equal(ErrPointerExpected))
+	})
+})
@@ -0,0 +1,102 @@
+package alligotor
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var errMalformedINI = errors.New("malformed ini")
+
+// iniExt is the normalized extension of ini files, which are parsed as ini without detecting the format.
+const iniExt = "ini"
+
+// parseINI parses ini formatted bytes into a nested map.
+// Section headers as well as keys are split by the separator to build nested maps, so
+// "[database]" followed by "host=localhost" results in {"database": {"host": "localhost"}}.
+// Keys outside of any section are added to the root of the map.
+// Lines starting with ";" or "#" are treated as comments.
+func parseINI(separator string, data []byte) (map[string]interface{}, error) {
+	root := map[string]interface{}{}
+	section := root
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("%w: invalid section header in line %d", errMalformedINI, lineNumber)
+			}
+
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if name == "" {
+				return nil, fmt.Errorf("%w: empty section header in line %d", errMalformedINI, lineNumber)
+			}
+
+			var err error
+			if section, err = iniSubMap(root, strings.Split(name, separator)); err != nil {
+				return nil, fmt.Errorf("%w in line %d", err, lineNumber)
+			}
+
+			continue
+		}
+
+		keyVal := strings.SplitN(line, "=", 2)
+		if len(keyVal) != 2 || strings.TrimSpace(keyVal[0]) == "" {
+			return nil, fmt.Errorf("%w: expected key=value in line %d", errMalformedINI, lineNumber)
+		}
+
+		keyPath := strings.Split(strings.TrimSpace(keyVal[0]), separator)
+
+		parent, err := iniSubMap(section, keyPath[:len(keyPath)-1])
+		if err != nil {
+			return nil, fmt.Errorf("%w in line %d", err, lineNumber)
+		}
+
+		parent[keyPath[len(keyPath)-1]] = unquoteINIValue(strings.TrimSpace(keyVal[1]))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return root, nil
+}
+
+// iniSubMap returns the nested map at the given path and creates it if it doesn't exist yet.
+func iniSubMap(m map[string]interface{}, path []string) (map[string]interface{}, error) {
+	for _, key := range path {
+		val, ok := m[key]
+		if !ok {
+			subMap := map[string]interface{}{}
+			m[key] = subMap
+			m = subMap
+
+			continue
+		}
+
+		subMap, ok := val.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %s is already set as a value", errMalformedINI, key)
+		}
+
+		m = subMap
+	}
+
+	return m, nil
+}
+
+func unquoteINIValue(value string) string {
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		return value[1 : len(value)-1]
+	}
+
+	return value
+}
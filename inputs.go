@@ -0,0 +1,93 @@
+package alligotor
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+// Inputs defines the content of the sources for Collector.GetFrom instead of the process environment.
+// Env contains the environment variables, Args the command line arguments and Files the content of the
+// config files by their paths.
+type Inputs struct {
+	Env   map[string]string
+	Args  []string
+	Files map[string][]byte
+}
+
+// GetFrom works like Get but reads the sources from in instead of the environment variables, the command line
+// arguments and the file system, e.g. to test the configuration without modifying the global state.
+// The Files are used instead of the Locations, URLs and stdin and are applied in the order of their paths,
+// so with the default order later paths take precedence. The file format is detected from the extension or
+// the content like for other files. Paths set with the PathEnvVar or PathFlag are looked up in the Files as well.
+// So are the paths of file env vars (see EnvConfig.FileSuffix), those files are not loaded as config files.
+// The Args are used instead of the Flags.Args. Nil values are treated like empty ones.
+// The configuration of the Collector (e.g. prefixes or disabled sources) and the options are applied as usual.
+func (c *Collector) GetFrom(v interface{}, in Inputs, opts ...Option) error {
+	return c.getWithOptions(v, append(append([]Option{}, opts...), withInputs(&in)), true)
+}
+
+// withInputs sets the inputs that are used instead of the process environment for a single call.
+func withInputs(in *Inputs) Option {
+	return func(c *Collector) {
+		c.inputs = in
+	}
+}
+
+// envVars returns the environment variables from the inputs if they're set, otherwise the ones of the process.
+func (c *Collector) envVars() map[string]string {
+	if c.inputs == nil {
+		return getEnvAsMap()
+	}
+
+	vars := make(map[string]string, len(c.inputs.Env))
+	for name, value := range c.inputs.Env {
+		vars[name] = value
+	}
+
+	return vars
+}
+
+// getenv returns the value of the environment variable from the inputs if they're set, otherwise from the process.
+func (c *Collector) getenv(name string) string {
+	if c.inputs == nil {
+		return os.Getenv(name)
+	}
+
+	return c.inputs.Env[name]
+}
+
+// inputFilePaths returns the sorted paths of the files from the inputs
+// without the ones that are referenced by file env vars (e.g. DB_PASSWORD_FILE).
+func (c *Collector) inputFilePaths() []string {
+	envFilePaths := map[string]bool{}
+
+	if !c.Env.Disabled && c.Env.FileSuffix != "" {
+		for name, value := range c.inputs.Env {
+			if strings.HasSuffix(name, strings.ToUpper(c.Env.FileSuffix)) {
+				envFilePaths[value] = true
+			}
+		}
+	}
+
+	filePaths := make([]string, 0, len(c.inputs.Files))
+	for filePath := range c.inputs.Files {
+		if !envFilePaths[filePath] {
+			filePaths = append(filePaths, filePath)
+		}
+	}
+
+	sort.Strings(filePaths)
+
+	return filePaths
+}
+
+// readInputFile returns the content of the file from the inputs, or an error like os.ReadFile if it doesn't exist.
+func (c *Collector) readInputFile(filePath string) ([]byte, error) {
+	fileBytes, ok := c.inputs.Files[filePath]
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: filePath, Err: os.ErrNotExist}
+	}
+
+	return fileBytes, nil
+}
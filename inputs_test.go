@@ -0,0 +1,103 @@
+package alligotor
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetFrom", func() {
+	type config struct {
+		Port    int
+		Name    string
+		Verbose bool
+		Server  struct {
+			Host string
+		}
+	}
+
+	var c *Collector
+
+	BeforeEach(func() {
+		c = &Collector{
+			Files: FilesConfig{Locations: []string{"/does/not/exist"}, BaseName: "config", Separator: "."},
+			Env:   EnvConfig{Prefix: "INPUTS", Separator: "_"},
+			Flags: FlagsConfig{Separator: "-"},
+		}
+
+		Expect(os.Setenv("INPUTS_NAME", "process")).To(Succeed())
+	})
+	AfterEach(func() {
+		Expect(os.Unsetenv("INPUTS_NAME")).To(Succeed())
+	})
+
+	It("reads the sources from the inputs", func() {
+		cfg := config{}
+		Expect(c.GetFrom(&cfg, Inputs{
+			Files: map[string][]byte{
+				"config.yaml": []byte("port: 1\nname: file\nserver:\n  host: a\n"),
+				"local.json":  []byte(`{"server": {"host": "b"}}`),
+			},
+			Env:  map[string]string{"INPUTS_NAME": "env"},
+			Args: []string{"--verbose"},
+		})).To(Succeed())
+
+		Expect(cfg.Port).To(Equal(1))
+		Expect(cfg.Name).To(Equal("env"))
+		Expect(cfg.Verbose).To(BeTrue())
+		Expect(cfg.Server.Host).To(Equal("b"))
+		Expect(c.LoadedFiles()).To(Equal([]string{"config.yaml", "local.json"}))
+	})
+	It("doesn't read the process environment", func() {
+		cfg := config{Name: "default"}
+		Expect(c.GetFrom(&cfg, Inputs{})).To(Succeed())
+		Expect(cfg.Name).To(Equal("default"))
+
+		Expect(c.Get(&cfg, WithArgs())).To(Succeed())
+		Expect(cfg.Name).To(Equal("process"))
+	})
+	It("looks up the paths from the path flag and env var in the files", func() {
+		c.Files.PathFlag = "config"
+		c.Files.PathEnvVar = "INPUTS_CONFIG"
+		files := map[string][]byte{
+			"flag.yaml": []byte("port: 1"),
+			"env.yaml":  []byte("port: 2"),
+		}
+
+		cfg := config{}
+		Expect(c.GetFrom(&cfg, Inputs{
+			Files: files,
+			Env:   map[string]string{"INPUTS_CONFIG": "env.yaml"},
+			Args:  []string{"--config", "flag.yaml"},
+		})).To(Succeed())
+		Expect(cfg.Port).To(Equal(1))
+
+		Expect(c.GetFrom(&cfg, Inputs{Files: files, Env: map[string]string{"INPUTS_CONFIG": "env.yaml"}})).To(Succeed())
+		Expect(cfg.Port).To(Equal(2))
+
+		Expect(c.GetFrom(&cfg, Inputs{Files: files, Args: []string{"--config", "missing.yaml"}})).
+			To(MatchError(os.ErrNotExist))
+	})
+	It("looks up the paths from the file env vars in the files", func() {
+		c.Env.FileSuffix = "_FILE"
+
+		cfg := config{}
+		Expect(c.GetFrom(&cfg, Inputs{
+			Files: map[string][]byte{"/run/secrets/name": []byte("secret\n")},
+			Env:   map[string]string{"INPUTS_NAME_FILE": "/run/secrets/name"},
+		})).To(Succeed())
+		Expect(cfg.Name).To(Equal("secret"))
+
+		Expect(c.GetFrom(&cfg, Inputs{Env: map[string]string{"INPUTS_NAME_FILE": "/run/secrets/name"}})).
+			To(MatchError(os.ErrNotExist))
+	})
+	It("applies the options for the call only", func() {
+		cfg := config{}
+		Expect(c.GetFrom(&cfg, Inputs{Env: map[string]string{"OTHER_NAME": "other"}}, WithEnvPrefix("OTHER"))).
+			To(Succeed())
+		Expect(cfg.Name).To(Equal("other"))
+		Expect(c.Env.Prefix).To(Equal("INPUTS"))
+		Expect(c.inputs).To(BeNil())
+	})
+})
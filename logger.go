@@ -0,0 +1,38 @@
+package alligotor
+
+// LogLevel is the level of a log event, see Logger.
+type LogLevel int
+
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelWarn
+)
+
+// String returns the name of the level.
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "debug"
+	case LogLevelInfo:
+		return "info"
+	case LogLevelWarn:
+		return "warn"
+	default:
+		return "unknown"
+	}
+}
+
+// Logger receives structured events about how the configuration is resolved, e.g. to debug which files are loaded
+// and which source sets a field. kv contains alternating keys and values like in many structured logging packages,
+// so it can easily be adapted to them.
+type Logger interface {
+	Log(level LogLevel, msg string, kv ...interface{})
+}
+
+// log passes the event to the Collector's Logger if it is set.
+func (c *Collector) log(level LogLevel, msg string, kv ...interface{}) {
+	if c.Logger != nil {
+		c.Logger.Log(level, msg, kv...)
+	}
+}
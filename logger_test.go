@@ -0,0 +1,78 @@
+package alligotor
+
+import (
+	"os"
+	"path"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type testLogEvent struct {
+	level LogLevel
+	msg   string
+	kv    []interface{}
+}
+
+type testLogger struct {
+	events []testLogEvent
+}
+
+func (l *testLogger) Log(level LogLevel, msg string, kv ...interface{}) {
+	l.events = append(l.events, testLogEvent{level: level, msg: msg, kv: kv})
+}
+
+func (l *testLogger) messages() []string {
+	messages := make([]string, 0, len(l.events))
+	for _, event := range l.events {
+		messages = append(messages, event.msg)
+	}
+
+	return messages
+}
+
+var _ = Describe("Logger", func() {
+	var c *Collector
+	var logger *testLogger
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "tests*")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		logger = &testLogger{}
+		c = &Collector{
+			Files:  FilesConfig{Locations: []string{dir}, BaseName: "config", Separator: "."},
+			Env:    EnvConfig{Disabled: true},
+			Flags:  FlagsConfig{Separator: "-", Args: []string{"--host", "flag"}},
+			Logger: logger,
+		}
+	})
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("logs loaded files and set fields", func() {
+		filePath := path.Join(dir, "config.json")
+		Expect(os.WriteFile(filePath, []byte(`{"port":1}`), 0600)).To(Succeed())
+		cfg := struct {
+			Host string
+			Port int
+		}{}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(logger.messages()).To(Equal([]string{"config files found", "config file loaded", "field set", "field set"}))
+		Expect(logger.events[1]).To(Equal(testLogEvent{LogLevelInfo, "config file loaded", []interface{}{"file", filePath}}))
+		Expect(logger.events[3].kv).To(Equal([]interface{}{"field", "Host", "source", SourceFlag, "key", "host", "raw", "flag"}))
+	})
+	It("logs if no file is found", func() {
+		cfg := struct{ Host string }{}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(logger.events[0]).To(Equal(testLogEvent{LogLevelDebug, "no config file found", nil}))
+	})
+	It("names the levels", func() {
+		Expect(LogLevelWarn.String()).To(Equal("warn"))
+	})
+})
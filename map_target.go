@@ -0,0 +1,50 @@
+package alligotor
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// getMap reads the config files into the map[string]interface{} target. The values of the files are merged in the
+// order in which the files are applied, nested objects are merged recursively and keys are matched like in files.
+// The existing entries of the target are used as defaults, the target is replaced with a new map afterwards.
+func (c *Collector) getMap(target reflect.Value) error {
+	if target.Type() != reflect.TypeOf(map[string]interface{}{}) {
+		return fmt.Errorf("%w: only map[string]interface{} is supported as map target, not %s",
+			ErrUnsupportedType, target.Type())
+	}
+
+	merged := newCiMap(withSeparator(c.Files.Separator))
+	merged.m = merged.merge(merged.m, deepCopyMap(target.Interface().(map[string]interface{})))
+
+	if !c.Files.Disabled {
+		err := c.forEachFile(func(_ string, m *ciMap) error {
+			merged.m = merged.merge(merged.m, m.m)
+
+			return nil
+		})
+		if err != nil && (c.Files.Required || !errors.Is(err, ErrNoFileFound)) {
+			return err
+		}
+	}
+
+	target.Set(reflect.ValueOf(merged.m))
+
+	return nil
+}
+
+// deepCopyMap returns a copy of m in which all nested maps are copied as well, so merging into it doesn't modify m.
+func deepCopyMap(m map[string]interface{}) map[string]interface{} {
+	c := make(map[string]interface{}, len(m))
+
+	for key, val := range m {
+		if nested, ok := val.(map[string]interface{}); ok {
+			val = deepCopyMap(nested)
+		}
+
+		c[key] = val
+	}
+
+	return c
+}
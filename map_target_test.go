@@ -0,0 +1,76 @@
+package alligotor
+
+import (
+	"bytes"
+	"os"
+	"path"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("map target", func() {
+	var c *Collector
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "tests*")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		c = &Collector{
+			Files: FilesConfig{Locations: []string{dir + "/*"}, Separator: "."},
+			Env:   EnvConfig{Separator: "_"},
+			Flags: FlagsConfig{Separator: "-", Args: []string{"--port", "3"}},
+		}
+	})
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("merges the files into the map", func() {
+		Expect(os.WriteFile(path.Join(dir, "10-base.yaml"), []byte("port: 1\ndb:\n  host: a\n  user: u\n"), 0600)).
+			To(Succeed())
+		Expect(os.WriteFile(path.Join(dir, "20-override.json"), []byte(`{"DB":{"Host":"b"}}`), 0600)).To(Succeed())
+
+		m := map[string]interface{}{"timeout": "5s", "port": 0}
+		Expect(c.Get(&m)).To(Succeed())
+		Expect(m).To(Equal(map[string]interface{}{
+			"timeout": "5s",
+			"port":    1,
+			"DB":      map[string]interface{}{"Host": "b", "user": "u"},
+		}))
+	})
+	It("doesn't modify the map in Explain", func() {
+		Expect(os.WriteFile(path.Join(dir, "config.yaml"), []byte("db:\n  host: a\n"), 0600)).To(Succeed())
+
+		m := map[string]interface{}{"db": map[string]interface{}{"host": "default"}}
+		_, err := c.Explain(&m)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(m).To(Equal(map[string]interface{}{"db": map[string]interface{}{"host": "default"}}))
+	})
+	It("works without files", func() {
+		var m map[string]interface{}
+		Expect(c.Get(&m)).To(Succeed())
+		Expect(m).To(BeEmpty())
+	})
+	It("rejects other maps", func() {
+		m := map[string]string{}
+		Expect(c.Get(&m)).To(MatchError(ErrUnsupportedType))
+	})
+	It("works with chains", func() {
+		Expect(os.WriteFile(path.Join(dir, "config.yaml"), []byte("port: 1"), 0600)).To(Succeed())
+
+		m := map[string]interface{}{}
+		Expect(Chain(c, c).Get(&m)).To(Succeed())
+		Expect(m).To(Equal(map[string]interface{}{"port": 1}))
+	})
+	It("is rejected by the functions that only support structs", func() {
+		var buf bytes.Buffer
+
+		m := map[string]interface{}{}
+		Expect(c.Save(&m, path.Join(dir, "saved.yaml"))).To(MatchError(ErrUnsupportedType))
+		Expect(c.PrintDefaults(&m, &buf)).To(MatchError(ErrUnsupportedType))
+		Expect(c.GetFromMap(&m, map[string]interface{}{})).To(MatchError(ErrUnsupportedType))
+	})
+})
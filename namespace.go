@@ -0,0 +1,36 @@
+package alligotor
+
+// envPrefix returns the prefix for environment variables, the Namespace is used if no Env.Prefix is set.
+func (c *Collector) envPrefix() string {
+	if c.Env.Prefix != "" {
+		return c.Env.Prefix
+	}
+
+	return c.Namespace
+}
+
+// flagPrefix returns the prefix for the generated flag names, the Namespace is used if no Flags.Prefix is set.
+func (c *Collector) flagPrefix() string {
+	if c.Flags.Prefix != "" {
+		return c.Flags.Prefix
+	}
+
+	return c.Namespace
+}
+
+// namespacedMap returns the nested map at the Namespace key if Files.UseNamespace is set, otherwise m.
+// If the key doesn't exist an empty map is returned, so the file doesn't contain any values for this Collector.
+func (c *Collector) namespacedMap(m *ciMap) *ciMap {
+	if !c.Files.UseNamespace || c.Namespace == "" {
+		return m
+	}
+
+	namespaced := newCiMap(withSeparator(m.separator))
+	if nested, ok := m.Get(c.Namespace); ok {
+		if nestedMap, ok := nested.(map[string]interface{}); ok {
+			namespaced.m = nestedMap
+		}
+	}
+
+	return namespaced
+}
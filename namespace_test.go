@@ -0,0 +1,70 @@
+package alligotor
+
+import (
+	"os"
+	"path"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Namespace", func() {
+	var c *Collector
+
+	BeforeEach(func() {
+		c = &Collector{
+			Files:     FilesConfig{Disabled: true, Separator: "."},
+			Env:       EnvConfig{Separator: "_"},
+			Flags:     FlagsConfig{Separator: "-", Args: []string{}},
+			Namespace: "nsapp",
+		}
+	})
+	AfterEach(func() {
+		Expect(os.Unsetenv("NSAPP_PORT")).To(Succeed())
+	})
+
+	It("is used as env and flag prefix", func() {
+		cfg := struct{ Port, Workers int }{}
+		Expect(os.Setenv("NSAPP_PORT", "1")).To(Succeed())
+		c.Flags.Args = []string{"--nsapp-workers", "2"}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Port).To(Equal(1))
+		Expect(cfg.Workers).To(Equal(2))
+	})
+	It("doesn't override explicit prefixes", func() {
+		c.Env.Prefix = "other"
+		c.Flags.Prefix = "other"
+
+		Expect(c.distinctEnvName(&field{Name: "Port"})).To(Equal("OTHER_PORT"))
+		Expect(c.longFlagName(&field{Name: "Port"})).To(Equal("other-port"))
+	})
+	It("is used as top level key in files if configured", func() {
+		cfg := struct{ Port int }{}
+		m := map[string]interface{}{"port": 1, "nsapp": map[string]interface{}{"port": 2}}
+
+		Expect(c.GetFromMap(&cfg, m)).To(Succeed())
+		Expect(cfg.Port).To(Equal(1))
+
+		c.Files.UseNamespace = true
+		Expect(c.GetFromMap(&cfg, m)).To(Succeed())
+		Expect(cfg.Port).To(Equal(2))
+
+		cfg.Port = 0
+		Expect(c.GetFromMap(&cfg, map[string]interface{}{"port": 1})).To(Succeed())
+		Expect(cfg.Port).To(Equal(0))
+	})
+	It("is used as top level key when saving if configured", func() {
+		dir, err := os.MkdirTemp("", "tests*")
+		Expect(err).ShouldNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		c.Files.UseNamespace = true
+		filePath := path.Join(dir, "config.json")
+		Expect(c.Save(&struct{ Port int }{Port: 3}, filePath)).To(Succeed())
+
+		content, err := os.ReadFile(filePath)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(content).To(MatchJSON(`{"nsapp": {"Port": 3}}`))
+	})
+})
@@ -0,0 +1,101 @@
+package alligotor
+
+import (
+	"strings"
+	"unicode"
+)
+
+// splitWords splits a Go identifier into its words on case boundaries and underscores.
+// Acronyms are kept together and digits are attached to the preceding word,
+// e.g. MaxConnections results in [Max Connections], APIKey in [API Key] and HTTP2Server in [HTTP2 Server].
+func splitWords(name string) []string {
+	var words []string
+
+	runes := []rune(name)
+	start := 0
+
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '_' {
+			if i > start {
+				words = append(words, string(runes[start:i]))
+			}
+
+			start = i + 1
+
+			continue
+		}
+
+		if i == start || !unicode.IsUpper(runes[i]) {
+			continue
+		}
+
+		prev := runes[i-1]
+		nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+		// a new word starts at an upper case letter after a lower case letter or digit (maxConnections)
+		// or at the last upper case letter of an acronym that is followed by a lower case letter (APIKey)
+		if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+
+	return words
+}
+
+// NamingStrategy converts a Go field name to the name that is used for the field in a configuration source.
+// It is applied to the name of every field separately, before the names of nested fields are joined
+// with the source's separator. The built-in strategies are CamelCase, SnakeCase, KebabCase and ScreamingSnakeCase,
+// but any func(string) string can be used.
+type NamingStrategy func(name string) string
+
+// CamelCase converts a Go field name to lower camel case, e.g. MaxConnections results in maxConnections
+// and APIKey in apiKey.
+func CamelCase(name string) string {
+	words := splitWords(name)
+	for i, word := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(word)
+
+			continue
+		}
+
+		words[i] = strings.ToUpper(word[:1]) + strings.ToLower(word[1:])
+	}
+
+	return strings.Join(words, "")
+}
+
+// SnakeCase converts a Go field name to snake case, e.g. MaxConnections results in max_connections.
+func SnakeCase(name string) string {
+	return strings.ToLower(strings.Join(splitWords(name), "_"))
+}
+
+// KebabCase converts a Go field name to kebab case, e.g. MaxConnections results in max-connections.
+func KebabCase(name string) string {
+	return strings.ToLower(strings.Join(splitWords(name), "-"))
+}
+
+// ScreamingSnakeCase converts a Go field name to screaming snake case, e.g. MaxConnections results in MAX_CONNECTIONS.
+func ScreamingSnakeCase(name string) string {
+	return strings.ToUpper(strings.Join(splitWords(name), "_"))
+}
+
+// nameWith returns the field's name with the names of all parent fields converted with the naming strategy
+// and joined by the separator. If naming is nil the Go field names are used as they are.
+func (f *field) nameWith(separator string, naming NamingStrategy) string {
+	if naming == nil {
+		return f.FullName(separator)
+	}
+
+	names := make([]string, 0, len(f.Base)+1)
+	for _, name := range append(append([]string{}, f.Base...), f.Name) {
+		names = append(names, naming(name))
+	}
+
+	return strings.Join(names, separator)
+}
@@ -0,0 +1,56 @@
+package alligotor
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("naming", func() {
+	Describe("splitWords", func() {
+		It("splits on case boundaries", func() {
+			Expect(splitWords("Port")).To(Equal([]string{"Port"}))
+			Expect(splitWords("MaxConnections")).To(Equal([]string{"Max", "Connections"}))
+			Expect(splitWords("maxConnections")).To(Equal([]string{"max", "Connections"}))
+		})
+		It("keeps acronyms together", func() {
+			Expect(splitWords("APIKey")).To(Equal([]string{"API", "Key"}))
+			Expect(splitWords("ServerURL")).To(Equal([]string{"Server", "URL"}))
+		})
+		It("attaches digits to the preceding word", func() {
+			Expect(splitWords("HTTP2Server")).To(Equal([]string{"HTTP2", "Server"}))
+		})
+		It("splits on underscores", func() {
+			Expect(splitWords("Db_Host")).To(Equal([]string{"Db", "Host"}))
+		})
+		It("returns nil for empty names", func() {
+			Expect(splitWords("")).To(BeNil())
+		})
+	})
+	Describe("NamingStrategy", func() {
+		It("provides built-in strategies", func() {
+			Expect(CamelCase("MaxConnections")).To(Equal("maxConnections"))
+			Expect(CamelCase("APIKey")).To(Equal("apiKey"))
+			Expect(SnakeCase("MaxConnections")).To(Equal("max_connections"))
+			Expect(KebabCase("APIKey")).To(Equal("api-key"))
+			Expect(ScreamingSnakeCase("ServerURL")).To(Equal("SERVER_URL"))
+		})
+		It("is applied to every field name", func() {
+			f := &field{Base: []string{"DBConfig"}, Name: "MaxConnections"}
+			Expect(f.nameWith(".", nil)).To(Equal("DBConfig.MaxConnections"))
+			Expect(f.nameWith(".", KebabCase)).To(Equal("db-config.max-connections"))
+			Expect(f.nameWith("_", func(name string) string { return name[:1] })).To(Equal("D_M"))
+		})
+		It("is used for generated names of all sources", func() {
+			c := &Collector{
+				Files: FilesConfig{Separator: ".", Naming: CamelCase},
+				Env:   EnvConfig{Separator: "__", Naming: SnakeCase},
+				Flags: FlagsConfig{Separator: ".", Naming: KebabCase},
+			}
+			f := &field{Base: []string{"DB"}, Name: "MaxConnections"}
+
+			Expect(c.fileFieldName(f)).To(Equal("db.maxConnections"))
+			Expect(c.distinctEnvName(f)).To(Equal("DB__MAX_CONNECTIONS"))
+			Expect(c.longFlagName(f)).To(Equal("db.max-connections"))
+		})
+	})
+})
@@ -0,0 +1,67 @@
+package alligotor
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrNotOneOf is returned if a value is not one of the allowed values defined with the oneof key in the struct tag.
+var ErrNotOneOf = errors.New("value not allowed")
+
+// checkOneOf checks that the string fields and the elements of string slices are one of the allowed values
+// defined in the struct tags. Empty strings and nil pointers are not checked.
+func checkOneOf(fields []*field) error {
+	for _, f := range fields {
+		if len(f.Config.OneOf) == 0 {
+			continue
+		}
+
+		if !isStringOrStringSlice(f.valueType()) {
+			return fmt.Errorf("%w: oneof is only supported for strings, not for %s", ErrUnsupportedType, f.FullName("."))
+		}
+
+		if !f.Value.IsValid() {
+			continue
+		}
+
+		var values []string
+
+		if f.Value.Kind() == reflect.String {
+			values = []string{f.Value.String()}
+		} else {
+			for i := 0; i < f.Value.Len(); i++ {
+				values = append(values, f.Value.Index(i).String())
+			}
+		}
+
+		for _, value := range values {
+			if value == "" || isOneOf(value, f.Config.OneOf, f.Config.IgnoreCase) {
+				continue
+			}
+
+			return fmt.Errorf(
+				"%w: %s is %q, allowed values are %s",
+				ErrNotOneOf, f.FullName("."), value, strings.Join(f.Config.OneOf, ", "),
+			)
+		}
+	}
+
+	return nil
+}
+
+// isStringOrStringSlice returns true for strings and slices of strings, including other types with these kinds.
+func isStringOrStringSlice(t reflect.Type) bool {
+	return t.Kind() == reflect.String || t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.String
+}
+
+func isOneOf(value string, allowed []string, ignoreCase bool) bool {
+	for _, a := range allowed {
+		if a == value || ignoreCase && strings.EqualFold(a, value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,97 @@
+package alligotor
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("oneof", func() {
+	var c *Collector
+
+	BeforeEach(func() {
+		c = &Collector{
+			Files: FilesConfig{Disabled: true},
+			Env:   EnvConfig{Disabled: true},
+			Flags: FlagsConfig{Separator: "-"},
+		}
+	})
+
+	It("accepts allowed values", func() {
+		cfg := struct {
+			LogLevel string   `config:"oneof=debug info warn error"`
+			Features []string `config:"oneof=a b"`
+		}{}
+		c.Flags.Args = []string{"--loglevel", "warn", "--features", "b,a"}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.LogLevel).To(Equal("warn"))
+	})
+	It("returns error with the allowed values for other values", func() {
+		cfg := struct {
+			LogLevel string `config:"oneof=debug info"`
+		}{}
+		c.Flags.Args = []string{"--loglevel", "trace"}
+
+		err := c.Get(&cfg)
+		Expect(errors.Is(err, ErrNotOneOf)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("debug, info"))
+	})
+	It("checks the elements of string slices", func() {
+		cfg := struct {
+			Features []string `config:"oneof=a b"`
+		}{}
+		c.Flags.Args = []string{"--features", "a,c"}
+
+		Expect(errors.Is(c.Get(&cfg), ErrNotOneOf)).To(BeTrue())
+	})
+	It("is case sensitive by default", func() {
+		cfg := struct {
+			LogLevel string `config:"oneof=debug info"`
+		}{}
+		c.Flags.Args = []string{"--loglevel", "INFO"}
+
+		Expect(errors.Is(c.Get(&cfg), ErrNotOneOf)).To(BeTrue())
+	})
+	It("ignores case if configured", func() {
+		cfg := struct {
+			LogLevel string `config:"oneof=debug info,ignorecase"`
+		}{}
+		c.Flags.Args = []string{"--loglevel", "INFO"}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+	})
+	It("doesn't check empty values", func() {
+		cfg := struct {
+			LogLevel string `config:"oneof=debug info"`
+		}{}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+	})
+	It("returns error for fields that are not strings", func() {
+		cfg := struct {
+			Port int `config:"oneof=80 443"`
+		}{}
+
+		Expect(errors.Is(c.Get(&cfg), ErrUnsupportedType)).To(BeTrue())
+
+		ptrCfg := struct {
+			Port *int `config:"oneof=80 443"`
+		}{}
+
+		Expect(errors.Is(c.Get(&ptrCfg), ErrUnsupportedType)).To(BeTrue())
+	})
+	It("skips nil pointers and checks the values of other pointers", func() {
+		cfg := struct {
+			Level *string `config:"oneof=a b"`
+		}{}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Level).To(BeNil())
+
+		level := "c"
+		cfg.Level = &level
+		Expect(errors.Is(c.Get(&cfg), ErrNotOneOf)).To(BeTrue())
+	})
+})
@@ -0,0 +1,78 @@
+package alligotor
+
+import (
+	"reflect"
+	"strings"
+)
+
+// optionalSection is a nil pointer to a struct that was allocated to be able to read its fields.
+type optionalSection struct {
+	ptr  reflect.Value
+	path string
+}
+
+// allocateOptionalSections allocates all nil pointers to structs in value recursively
+// and returns them, parents before their children.
+// Pointers to struct types that are already on the path (e.g. Next *Node in Node) are not allocated,
+// otherwise self-referential types would be allocated infinitely.
+func allocateOptionalSections(value reflect.Value, base ...string) []optionalSection {
+	return allocateSections(value, base, map[reflect.Type]bool{value.Type(): true})
+}
+
+func allocateSections(value reflect.Value, base []string, onPath map[reflect.Type]bool) []optionalSection {
+	var sections []optionalSection
+
+	for i := 0; i < value.NumField(); i++ {
+		fieldValue := value.Field(i)
+		if !fieldValue.CanSet() {
+			continue
+		}
+
+		structField := value.Type().Field(i)
+
+		// invalid struct tags are reported by getFieldsConfigsFromValue
+		config, _ := readParameterConfig(structField.Tag.Get(tag))
+
+		path := append(append([]string{}, base...), configName(structField, config))
+
+		if fieldValue.Kind() == reflect.Ptr && fieldValue.IsNil() && fieldValue.Type().Elem().Kind() == reflect.Struct {
+			if onPath[fieldValue.Type().Elem()] {
+				continue
+			}
+
+			fieldValue.Set(reflect.New(fieldValue.Type().Elem()))
+			sections = append(sections, optionalSection{ptr: fieldValue, path: strings.Join(path, ".")})
+		}
+
+		fieldValue = reflect.Indirect(fieldValue)
+		if fieldValue.Kind() == reflect.Struct && !isUnmarshaler(fieldValue.Type()) && !onPath[fieldValue.Type()] {
+			onPath[fieldValue.Type()] = true
+			sections = append(sections, allocateSections(fieldValue, path, onPath)...)
+			delete(onPath, fieldValue.Type())
+		}
+	}
+
+	return sections
+}
+
+// resetUntouchedSections sets the pointers of the sections back to nil if none of their fields was set from a source,
+// so optional sections stay nil if they are not configured.
+func (c *Collector) resetUntouchedSections(sections []optionalSection) {
+	// children are reset first, so their parents are checked for touched fields afterwards
+	for i := len(sections) - 1; i >= 0; i-- {
+		if !c.isTouched(sections[i].path) {
+			sections[i].ptr.Set(reflect.Zero(sections[i].ptr.Type()))
+		}
+	}
+}
+
+// isTouched returns true if the field at path or any of its children was set from a source.
+func (c *Collector) isTouched(path string) bool {
+	for touchedPath := range c.touched {
+		if touchedPath == path || strings.HasPrefix(touchedPath, path+".") {
+			return true
+		}
+	}
+
+	return false
+}
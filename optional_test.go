@@ -0,0 +1,94 @@
+package alligotor
+
+import (
+	"os"
+	"path"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("optional sections", func() {
+	type tlsConfig struct {
+		Cert string
+		Key  string
+	}
+
+	type optionalConfig struct {
+		Port   int
+		TLS    *tlsConfig
+		Server struct {
+			Auth *struct{ Token string }
+		}
+	}
+
+	var dir string
+	var c *Collector
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "tests*")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		c = &Collector{
+			Files: FilesConfig{Locations: []string{dir}, BaseName: "config", Separator: "."},
+			Env:   EnvConfig{Disabled: true},
+			Flags: FlagsConfig{Separator: "-", Args: []string{}},
+		}
+	})
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("keeps nil pointers if nothing is set", func() {
+		cfg := optionalConfig{}
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.TLS).To(BeNil())
+		Expect(cfg.Server.Auth).To(BeNil())
+	})
+	It("allocates pointers if a field is set", func() {
+		c.Flags.Args = []string{"--tls-cert", "cert.pem", "--server-auth-token", "secret"}
+
+		cfg := optionalConfig{}
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.TLS).To(Equal(&tlsConfig{Cert: "cert.pem"}))
+		Expect(cfg.Server.Auth.Token).To(Equal("secret"))
+	})
+	It("allocates pointers for whole struct values", func() {
+		Expect(os.WriteFile(path.Join(dir, "config.json"), []byte(`{"tls": {"key": "key.pem"}}`), 0600)).To(Succeed())
+
+		cfg := optionalConfig{}
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.TLS).To(Equal(&tlsConfig{Key: "key.pem"}))
+		Expect(cfg.Server.Auth).To(BeNil())
+	})
+	It("keeps nil pointers on errors", func() {
+		c.Flags.Args = []string{"--port", "invalid"}
+
+		cfg := optionalConfig{}
+		Expect(c.Get(&cfg)).NotTo(Succeed())
+		Expect(cfg.TLS).To(BeNil())
+	})
+	It("keeps pointers that are set as defaults", func() {
+		cfg := optionalConfig{TLS: &tlsConfig{Cert: "default.pem"}}
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.TLS).To(Equal(&tlsConfig{Cert: "default.pem"}))
+	})
+	It("doesn't allocate self-referential types infinitely", func(done Done) {
+		type node struct {
+			Name string
+			Next *node
+		}
+
+		cfg := struct{ Root node }{}
+		Expect(c.Get(&cfg, WithoutFiles(), WithArgs("--root-name", "a"))).To(Succeed())
+		Expect(cfg.Root.Name).To(Equal("a"))
+		Expect(cfg.Root.Next).To(BeNil())
+
+		infos, err := c.Describe(&cfg)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(infos).To(HaveLen(3))
+
+		close(done)
+	}, 5)
+})
@@ -0,0 +1,145 @@
+package alligotor
+
+import "reflect"
+
+// Option changes the configuration of a Collector.
+// Options can be passed to NewCollector to create a Collector
+// or to Collector.Get to override the configuration for a single call.
+type Option func(*Collector)
+
+// WithFiles sets the locations that are searched for config files.
+func WithFiles(locations ...string) Option {
+	return func(c *Collector) {
+		c.Files.Locations = locations
+	}
+}
+
+// WithBaseName sets the base name of the config files.
+func WithBaseName(baseName string) Option {
+	return func(c *Collector) {
+		c.Files.BaseName = baseName
+	}
+}
+
+// WithFileSeparator sets the separator for nested keys in config files.
+func WithFileSeparator(separator string) Option {
+	return func(c *Collector) {
+		c.Files.Separator = separator
+	}
+}
+
+// WithURLs sets the http(s) URLs config files are loaded from.
+func WithURLs(urls ...string) Option {
+	return func(c *Collector) {
+		c.Files.URLs = urls
+	}
+}
+
+// WithoutFiles disables reading config files.
+func WithoutFiles() Option {
+	return func(c *Collector) {
+		c.Files.Disabled = true
+	}
+}
+
+// WithoutEnv disables reading environment variables.
+func WithoutEnv() Option {
+	return func(c *Collector) {
+		c.Env.Disabled = true
+	}
+}
+
+// WithoutFlags disables reading command line flags.
+func WithoutFlags() Option {
+	return func(c *Collector) {
+		c.Flags.Disabled = true
+	}
+}
+
+// WithEnvPrefix sets the prefix for environment variables.
+func WithEnvPrefix(prefix string) Option {
+	return func(c *Collector) {
+		c.Env.Prefix = prefix
+	}
+}
+
+// WithEnvSeparator sets the separator for environment variable names.
+func WithEnvSeparator(separator string) Option {
+	return func(c *Collector) {
+		c.Env.Separator = separator
+	}
+}
+
+// WithFlagPrefix sets the prefix for generated long flag names.
+func WithFlagPrefix(prefix string) Option {
+	return func(c *Collector) {
+		c.Flags.Prefix = prefix
+	}
+}
+
+// WithFlagSeparator sets the separator for flag names.
+func WithFlagSeparator(separator string) Option {
+	return func(c *Collector) {
+		c.Flags.Separator = separator
+	}
+}
+
+// WithArgs sets the arguments that are parsed for flags instead of os.Args[1:].
+func WithArgs(args ...string) Option {
+	return func(c *Collector) {
+		c.Flags.Args = args
+	}
+}
+
+// withOptions returns a copy of the Collector's configuration with the options applied, so they only affect
+// a single call. Maps and slices are copied as well, so options can't modify the Collector through them,
+// e.g. by calling RegisterConverter. The state of a call (e.g. the loaded files) is not copied.
+func (c *Collector) withOptions(opts []Option) *Collector {
+	call := &Collector{
+		Files:            c.Files,
+		Env:              c.Env,
+		Flags:            c.Flags,
+		Namespace:        c.Namespace,
+		Sources:          append([]Source(nil), c.Sources...),
+		SourcesAfter:     c.SourcesAfter,
+		Logger:           c.Logger,
+		onSet:            c.onSet,
+		onDeprecatedName: c.onDeprecatedName,
+		stdin:            c.stdin,
+		inputs:           c.inputs,
+	}
+
+	if c.Groups != nil {
+		call.Groups = make(map[string]GroupRule, len(c.Groups))
+		for name, rule := range c.Groups {
+			call.Groups[name] = rule
+		}
+	}
+
+	if c.decoders != nil {
+		call.decoders = make(map[string]func([]byte) (map[string]interface{}, error), len(c.decoders))
+		for ext, decoder := range c.decoders {
+			call.decoders[ext] = decoder
+		}
+	}
+
+	if c.converters != nil {
+		call.converters = make(map[reflect.Type]func(string) (interface{}, error), len(c.converters))
+		for t, converter := range c.converters {
+			call.converters[t] = converter
+		}
+	}
+
+	if c.transforms != nil {
+		call.transforms = make(map[string]func(string) string, len(c.transforms))
+		for name, transform := range c.transforms {
+			call.transforms[name] = transform
+		}
+	}
+
+	for _, opt := range opts {
+		opt(call)
+	}
+
+	return call
+}
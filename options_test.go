@@ -0,0 +1,109 @@
+package alligotor
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Option", func() {
+	var c *Collector
+
+	BeforeEach(func() {
+		c = &Collector{
+			Files: FilesConfig{Disabled: true},
+			Env:   EnvConfig{Prefix: "OPTIONS", Separator: "_"},
+			Flags: FlagsConfig{Separator: "-", Args: []string{"--value", "flag"}},
+		}
+
+		Expect(os.Setenv("OPTIONS_VALUE", "env")).To(Succeed())
+		Expect(os.Setenv("OTHER_VALUE", "other")).To(Succeed())
+	})
+	AfterEach(func() {
+		Expect(os.Unsetenv("OPTIONS_VALUE")).To(Succeed())
+		Expect(os.Unsetenv("OTHER_VALUE")).To(Succeed())
+	})
+
+	It("overrides the configuration for a single call", func() {
+		cfg := struct{ Value string }{}
+
+		Expect(c.Get(&cfg, WithoutFlags())).To(Succeed())
+		Expect(cfg.Value).To(Equal("env"))
+
+		Expect(c.Get(&cfg, WithoutFlags(), WithEnvPrefix("OTHER"))).To(Succeed())
+		Expect(cfg.Value).To(Equal("other"))
+
+		Expect(c.Get(&cfg, WithArgs("--value", "args"))).To(Succeed())
+		Expect(cfg.Value).To(Equal("args"))
+
+		Expect(c.Flags.Disabled).To(BeFalse())
+		Expect(c.Env.Prefix).To(Equal("OPTIONS"))
+		Expect(c.Flags.Args).To(Equal([]string{"--value", "flag"}))
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Value).To(Equal("flag"))
+	})
+	It("doesn't modify any configuration of the Collector", func() {
+		cfg := struct{ Value string }{}
+
+		Expect(c.Get(&cfg, WithoutFlags(), func(c *Collector) {
+			c.Namespace = "leak"
+			c.Groups = map[string]GroupRule{"leak": GroupAtLeastOne}
+			c.Sources = append(c.Sources, testSource{"value": "source"})
+			c.RegisterTransform("leak", func(s string) string { return s })
+		})).To(Succeed())
+		Expect(cfg.Value).To(Equal("env"))
+
+		Expect(c.Get(&cfg, WithoutFlags(), WithoutEnv(), func(c *Collector) {
+			c.Sources = append(c.Sources, testSource{"value": "source"})
+		})).To(Succeed())
+		Expect(cfg.Value).To(Equal("source"))
+
+		Expect(c.Namespace).To(BeEmpty())
+		Expect(c.Groups).To(BeNil())
+		Expect(c.Sources).To(BeNil())
+		Expect(c.transforms).To(BeNil())
+	})
+	It("can disable all sources", func() {
+		cfg := struct{ Value string }{Value: "default"}
+
+		Expect(c.Get(&cfg, WithoutFiles(), WithoutEnv(), WithoutFlags())).To(Succeed())
+		Expect(cfg.Value).To(Equal("default"))
+	})
+
+	Describe("NewCollector", func() {
+		It("uses the default configuration", func() {
+			Expect(NewCollector()).To(Equal(&Collector{
+				Files: FilesConfig{Locations: []string{"."}, BaseName: "config", Separator: "."},
+				Env:   EnvConfig{Separator: "_", FileSuffix: "_FILE", TrimSpace: true},
+				Flags: FlagsConfig{Separator: "-"},
+			}))
+		})
+		It("applies the options", func() {
+			c := NewCollector(
+				WithFiles("/etc/app", "."),
+				WithBaseName("app"),
+				WithFileSeparator("/"),
+				WithURLs("https://example.com/app.json"),
+				WithEnvPrefix("APP"),
+				WithEnvSeparator("__"),
+				WithFlagPrefix("app"),
+				WithFlagSeparator("."),
+				WithArgs("--port", "1"),
+				WithoutFiles(),
+			)
+			Expect(c).To(Equal(&Collector{
+				Files: FilesConfig{
+					Locations: []string{"/etc/app", "."},
+					BaseName:  "app",
+					Separator: "/",
+					URLs:      []string{"https://example.com/app.json"},
+					Disabled:  true,
+				},
+				Env:   EnvConfig{Prefix: "APP", Separator: "__", FileSuffix: "_FILE", TrimSpace: true},
+				Flags: FlagsConfig{Prefix: "app", Separator: ".", Args: []string{"--port", "1"}},
+			}))
+		})
+	})
+})
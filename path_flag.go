@@ -0,0 +1,63 @@
+package alligotor
+
+import (
+	"os"
+	"strings"
+)
+
+const (
+	pathFlagUsage      = "path of the config file"
+	flagTerminator     = "--"
+	flagValueSeparator = "="
+)
+
+// flagArgs returns the Args of the inputs if they're set, otherwise the configured Flags.Args
+// or the command line arguments if they're nil.
+func (c *Collector) flagArgs() []string {
+	if c.inputs != nil {
+		return c.inputs.Args
+	}
+
+	if c.Flags.Args == nil {
+		return os.Args[1:]
+	}
+
+	return c.Flags.Args
+}
+
+// pathFromFlag returns the value of the PathFlag if it's set in the args, the last value wins.
+// The args are scanned without parsing them with a flag set, since the other flags are only parsed after the files
+// are read. Both --name value and --name=value are supported, with one or two dashes.
+func (c *Collector) pathFromFlag() (string, bool) {
+	if c.Files.PathFlag == "" || c.Flags.Disabled {
+		return "", false
+	}
+
+	args := c.flagArgs()
+
+	var (
+		path  string
+		found bool
+	)
+
+	for i := 0; i < len(args); i++ {
+		if args[i] == flagTerminator {
+			break
+		}
+
+		name := strings.TrimPrefix(strings.TrimPrefix(args[i], "-"), "-")
+		if name == args[i] {
+			continue
+		}
+
+		switch {
+		case name == c.Files.PathFlag && i+1 < len(args):
+			i++
+			path, found = args[i], true
+		case strings.HasPrefix(name, c.Files.PathFlag+flagValueSeparator):
+			path, found = strings.TrimPrefix(name, c.Files.PathFlag+flagValueSeparator), true
+		}
+	}
+
+	return path, found
+}
@@ -0,0 +1,86 @@
+package alligotor
+
+import (
+	"bytes"
+	"os"
+	"path"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PathFlag", func() {
+	var c *Collector
+	var dir, explicitFile string
+	var cfg struct {
+		Port int
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "tests*")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		explicitFile = path.Join(dir, "explicit.yaml")
+		Expect(os.WriteFile(explicitFile, []byte(`port: 1`), 0600)).To(Succeed())
+		Expect(os.WriteFile(path.Join(dir, "config.yaml"), []byte(`port: 2`), 0600)).To(Succeed())
+
+		cfg.Port = 0
+		c = &Collector{
+			Files: FilesConfig{Locations: []string{dir}, BaseName: "config", Separator: ".", PathFlag: "config"},
+			Env:   EnvConfig{Disabled: true},
+			Flags: FlagsConfig{Separator: "-"},
+		}
+	})
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("loads the file from the flag", func() {
+		c.Flags.Args = []string{"--config", explicitFile}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Port).To(Equal(1))
+		Expect(c.LoadedFiles()).To(Equal([]string{explicitFile}))
+	})
+	It("supports values after an equal sign and std flags", func() {
+		c.Flags.UseStdFlag = true
+		c.Flags.Args = []string{"-config=" + explicitFile, "-port", "3"}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Port).To(Equal(3))
+		Expect(c.LoadedFiles()).To(Equal([]string{explicitFile}))
+	})
+	It("discovers the files if the flag isn't set", func() {
+		c.Flags.Args = []string{"--", "--config", explicitFile}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Port).To(Equal(2))
+	})
+	It("takes precedence over the env var", func() {
+		c.Files.PathEnvVar = "PATHFLAGTEST_CONFIG"
+		Expect(os.Setenv("PATHFLAGTEST_CONFIG", path.Join(dir, "config.yaml"))).To(Succeed())
+		defer os.Unsetenv("PATHFLAGTEST_CONFIG")
+		c.Flags.Args = []string{"--config=" + explicitFile}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Port).To(Equal(1))
+	})
+	It("is ignored if the flags are disabled", func() {
+		c.Flags.Disabled = true
+		c.Flags.Args = []string{"--config", explicitFile}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Port).To(Equal(2))
+	})
+	It("is included in the usage", func() {
+		var buf bytes.Buffer
+		Expect(c.PrintDefaults(&cfg, &buf)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring("--config string"))
+	})
+	It("returns an error if a field uses the same flag", func() {
+		c.Files.PathFlag = "port"
+
+		Expect(c.Get(&cfg)).To(MatchError(ErrDuplicateName))
+	})
+})
@@ -0,0 +1,65 @@
+package alligotor
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("prefix", func() {
+	type dbConfig struct {
+		Host string
+		Port int
+	}
+
+	var c *Collector
+
+	BeforeEach(func() {
+		c = &Collector{
+			Files: FilesConfig{Disabled: true, Separator: "."},
+			Env:   EnvConfig{Prefix: "PREFIXTEST", Separator: "_"},
+			Flags: FlagsConfig{Separator: "-"},
+		}
+	})
+
+	It("uses the prefix instead of the field name for the children", func() {
+		cfg := struct {
+			Db dbConfig `config:"prefix=database" json:"db"`
+		}{}
+		Expect(os.Setenv("PREFIXTEST_DATABASE_HOST", "host")).To(Succeed())
+		defer os.Unsetenv("PREFIXTEST_DATABASE_HOST")
+		c.Flags.Args = []string{"--database-port", "1"}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Db).To(Equal(dbConfig{Host: "host", Port: 1}))
+
+		Expect(c.GetFromMap(&cfg, map[string]interface{}{"database": map[string]interface{}{"port": 2}})).To(Succeed())
+		Expect(cfg.Db.Port).To(Equal(2))
+	})
+	It("supports optional sections", func() {
+		cfg := struct {
+			Db    *dbConfig `config:"prefix=database"`
+			Other *dbConfig `config:"prefix=other"`
+		}{}
+		c.Flags.Args = []string{"--database-port", "1"}
+
+		hits, err := c.Explain(&cfg)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hits).To(HaveLen(1))
+		Expect(hits[0].Field).To(Equal("database.Port"))
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Db).To(Equal(&dbConfig{Port: 1}))
+		Expect(cfg.Other).To(BeNil())
+	})
+	It("is ignored for fields that are no structs", func() {
+		cfg := struct {
+			Port int `config:"prefix=other"`
+		}{}
+		c.Flags.Args = []string{"--port", "1"}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Port).To(Equal(1))
+	})
+})
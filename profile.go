@@ -0,0 +1,44 @@
+package alligotor
+
+const profilesKey = "profiles"
+
+// profile returns the name of the selected profile, the value of the ProfileEnvVar takes precedence over the Profile.
+func (c *Collector) profile() string {
+	if c.Files.ProfileEnvVar != "" {
+		if profile := c.getenv(c.Files.ProfileEnvVar); profile != "" {
+			return profile
+		}
+	}
+
+	return c.Files.Profile
+}
+
+// applyProfile returns m without the profiles key, merged with the values of the selected profile if there is one.
+// m is returned as it is if profiles are not configured.
+func (c *Collector) applyProfile(m *ciMap) *ciMap {
+	if c.Files.Profile == "" && c.Files.ProfileEnvVar == "" {
+		return m
+	}
+
+	var profileValues map[string]interface{}
+
+	if profile := c.profile(); profile != "" {
+		if values, ok := m.Get(profilesKey + m.separator + profile); ok {
+			profileValues, _ = values.(map[string]interface{})
+		}
+	}
+
+	for key := range m.m {
+		if m.normalizeKey(key) == profilesKey {
+			delete(m.m, key)
+		}
+	}
+
+	if profileValues != nil {
+		c.log(LogLevelDebug, "config profile applied", "profile", c.profile())
+
+		m.m = m.merge(m.m, profileValues)
+	}
+
+	return m
+}
@@ -0,0 +1,86 @@
+package alligotor
+
+import (
+	"os"
+	"path"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("profiles", func() {
+	type config struct {
+		Port int
+		DB   struct {
+			Host string
+			User string
+		}
+	}
+
+	var c *Collector
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "tests*")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(os.WriteFile(path.Join(dir, "config.yaml"), []byte(
+			"port: 8080\ndb:\n  host: localhost\n  user: app\n"+
+				"profiles:\n  production:\n    port: 80\n    db:\n      host: db.prod\n  staging:\n    port: 8081\n",
+		), 0600)).To(Succeed())
+
+		c = &Collector{
+			Files: FilesConfig{Locations: []string{dir}, BaseName: "config", Separator: ".", Strict: true},
+			Env:   EnvConfig{Disabled: true},
+			Flags: FlagsConfig{Disabled: true},
+		}
+	})
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("merges the selected profile over the base values", func() {
+		c.Files.Profile = "production"
+
+		cfg := config{}
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Port).To(Equal(80))
+		Expect(cfg.DB.Host).To(Equal("db.prod"))
+		Expect(cfg.DB.User).To(Equal("app"))
+	})
+	It("selects the profile with the env var", func() {
+		c.Files.Profile = "production"
+		c.Files.ProfileEnvVar = "PROFILETEST_ENV"
+		Expect(os.Setenv("PROFILETEST_ENV", "staging")).To(Succeed())
+		defer os.Unsetenv("PROFILETEST_ENV")
+
+		cfg := config{}
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Port).To(Equal(8081))
+		Expect(cfg.DB.Host).To(Equal("localhost"))
+	})
+	It("only uses the base values if the profile doesn't exist", func() {
+		c.Files.ProfileEnvVar = "PROFILETEST_ENV"
+
+		cfg := config{}
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Port).To(Equal(8080))
+	})
+	It("keeps the profiles key if profiles are not configured", func() {
+		cfg := config{}
+		Expect(c.Get(&cfg)).To(MatchError(ErrUnknownKey))
+	})
+	It("applies the profile in GetFromMap", func() {
+		c.Files.Profile = "production"
+		m := map[string]interface{}{
+			"port":     1,
+			"profiles": map[string]interface{}{"production": map[string]interface{}{"port": 2}},
+		}
+
+		cfg := config{}
+		Expect(c.GetFromMap(&cfg, m)).To(Succeed())
+		Expect(cfg.Port).To(Equal(2))
+		Expect(m).To(HaveKey("profiles"))
+	})
+})
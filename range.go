@@ -0,0 +1,117 @@
+package alligotor
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+var (
+	// ErrInvalidBound is returned if a min or max value in a struct tag is not a number of the field's type.
+	ErrInvalidBound = errors.New("invalid bound")
+	// ErrOutOfRange is returned if a value is lower than its min or greater than its max value.
+	ErrOutOfRange = errors.New("value out of range")
+)
+
+// checkRanges checks that the numeric fields are within the bounds defined with the min and max keys in the struct tags.
+func checkRanges(fields []*field) error {
+	for _, f := range fields {
+		if f.Config.Min == "" && f.Config.Max == "" {
+			continue
+		}
+
+		if err := checkRange(f.valueType(), f.Value, f.Config.Min, f.Config.Max); err != nil {
+			return fmt.Errorf("%w of %s", err, f.FullName("."))
+		}
+	}
+
+	return nil
+}
+
+// checkRange checks that the numeric value of type t is within the given bounds. Empty bounds are not checked.
+// Invalid values (e.g. of nil pointers) are skipped, but the type still needs to be numeric.
+func checkRange(t reflect.Type, value reflect.Value, min, max string) error {
+	var compare func(bound string) (int, error)
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		compare = func(bound string) (int, error) {
+			b, err := strconv.ParseInt(bound, 10, 64)
+			return compareInts(value.Int(), b), err
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		compare = func(bound string) (int, error) {
+			b, err := strconv.ParseUint(bound, 10, 64)
+			return compareUints(value.Uint(), b), err
+		}
+	case reflect.Float32, reflect.Float64:
+		compare = func(bound string) (int, error) {
+			b, err := strconv.ParseFloat(bound, 64)
+			return compareFloats(value.Float(), b), err
+		}
+	default:
+		return fmt.Errorf("%w: min and max are only supported for numbers", ErrUnsupportedType)
+	}
+
+	if !value.IsValid() {
+		return nil
+	}
+
+	if min != "" {
+		cmp, err := compare(min)
+		if err != nil {
+			return fmt.Errorf("%w %q", ErrInvalidBound, min)
+		}
+
+		if cmp < 0 {
+			return fmt.Errorf("%w: %v is less than %s", ErrOutOfRange, value.Interface(), min)
+		}
+	}
+
+	if max != "" {
+		cmp, err := compare(max)
+		if err != nil {
+			return fmt.Errorf("%w %q", ErrInvalidBound, max)
+		}
+
+		if cmp > 0 {
+			return fmt.Errorf("%w: %v is greater than %s", ErrOutOfRange, value.Interface(), max)
+		}
+	}
+
+	return nil
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareUints(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,94 @@
+package alligotor
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ranges", func() {
+	var c *Collector
+
+	BeforeEach(func() {
+		c = &Collector{
+			Files: FilesConfig{Disabled: true},
+			Env:   EnvConfig{Disabled: true},
+			Flags: FlagsConfig{Separator: "-"},
+		}
+	})
+
+	It("accepts values within the range", func() {
+		cfg := struct {
+			Workers int     `config:"min=1,max=64"`
+			Ratio   float64 `config:"min=0,max=0.5"`
+			Size    uint    `config:"max=10"`
+		}{}
+		c.Flags.Args = []string{"--workers", "64", "--ratio", "0.25", "--size", "10"}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Workers).To(Equal(64))
+	})
+	It("returns error for values less than min", func() {
+		cfg := struct {
+			Workers int `config:"min=1,max=64"`
+		}{}
+		c.Flags.Args = []string{"--workers", "0"}
+
+		Expect(errors.Is(c.Get(&cfg), ErrOutOfRange)).To(BeTrue())
+	})
+	It("returns error for values greater than max", func() {
+		cfg := struct {
+			Ratio float64 `config:"max=0.5"`
+		}{}
+		c.Flags.Args = []string{"--ratio", "0.75"}
+
+		Expect(errors.Is(c.Get(&cfg), ErrOutOfRange)).To(BeTrue())
+	})
+	It("checks defaults", func() {
+		cfg := struct {
+			Workers int `config:"min=1"`
+		}{}
+
+		Expect(errors.Is(c.Get(&cfg), ErrOutOfRange)).To(BeTrue())
+	})
+	It("checks values set with GetFromMap", func() {
+		cfg := struct {
+			Workers int `config:"max=64"`
+		}{}
+
+		Expect(errors.Is(c.GetFromMap(&cfg, map[string]interface{}{"workers": 65}), ErrOutOfRange)).To(BeTrue())
+	})
+	It("returns error for bounds that don't match the field type", func() {
+		cfg := struct {
+			Workers int `config:"min=1.5"`
+		}{Workers: 2}
+
+		Expect(errors.Is(c.Get(&cfg), ErrInvalidBound)).To(BeTrue())
+	})
+	It("returns error for fields that are not numbers", func() {
+		cfg := struct {
+			Name string `config:"min=1"`
+		}{}
+
+		Expect(errors.Is(c.Get(&cfg), ErrUnsupportedType)).To(BeTrue())
+
+		ptrCfg := struct {
+			Name *string `config:"min=1"`
+		}{}
+
+		Expect(errors.Is(c.Get(&ptrCfg), ErrUnsupportedType)).To(BeTrue())
+	})
+	It("skips nil pointers and checks the values of other pointers", func() {
+		cfg := struct {
+			Port *int `config:"min=1"`
+		}{}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Port).To(BeNil())
+
+		port := 0
+		cfg.Port = &port
+		Expect(errors.Is(c.Get(&cfg), ErrOutOfRange)).To(BeTrue())
+	})
+})
@@ -0,0 +1,75 @@
+package alligotor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrRemoteFile is returned if a config file can't be loaded from one of the configured URLs.
+var ErrRemoteFile = errors.New("could not load remote config file")
+
+const defaultURLTimeout = 10 * time.Second
+
+// readFile reads the file at the given path, which can either be a local path or a http(s) URL.
+func (c *Collector) readFile(filePath string) ([]byte, error) {
+	if c.inputs != nil {
+		return c.readInputFile(filePath)
+	}
+
+	if filePath == stdinPath {
+		return c.readStdin()
+	}
+
+	if !isURL(filePath) {
+		return os.ReadFile(filePath)
+	}
+
+	return c.fetchURL(filePath)
+}
+
+func isURL(filePath string) bool {
+	return strings.HasPrefix(filePath, "http://") || strings.HasPrefix(filePath, "https://")
+}
+
+func (c *Collector) fetchURL(url string) ([]byte, error) {
+	client := c.Files.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	timeout := c.Files.URLTimeout
+	if timeout == 0 {
+		timeout = defaultURLTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w from %s: %v", ErrRemoteFile, url, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w from %s: %v", ErrRemoteFile, url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w from %s: unexpected status %s", ErrRemoteFile, url, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w from %s: %v", ErrRemoteFile, url, err)
+	}
+
+	return body, nil
+}
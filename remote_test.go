@@ -0,0 +1,59 @@
+package alligotor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("remote files", func() {
+	var server *httptest.Server
+	var c *Collector
+
+	BeforeEach(func() {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/config.json", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"port": 1234}`))
+		})
+		mux.HandleFunc("/config.yaml", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`port: 2345`))
+		})
+		mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(100 * time.Millisecond)
+		})
+		server = httptest.NewServer(mux)
+
+		c = &Collector{Files: FilesConfig{Separator: ".", HTTPClient: server.Client()}}
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("loads files from urls in order", func() {
+		c.Files.URLs = []string{server.URL + "/config.json", server.URL + "/config.yaml"}
+		cfg := struct{ Port int }{}
+
+		fields, err := getFieldsConfigsFromValue(reflect.ValueOf(&cfg).Elem())
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(c.readFiles(fields)).To(Succeed())
+		Expect(cfg.Port).To(Equal(2345))
+	})
+	It("returns an error distinguishable from no file found", func() {
+		c.Files.URLs = []string{server.URL + "/not-found"}
+
+		err := c.readFiles(nil)
+		Expect(err).To(MatchError(ErrRemoteFile))
+		Expect(err).NotTo(MatchError(ErrNoFileFound))
+		Expect(err.Error()).To(ContainSubstring("404"))
+	})
+	It("uses the timeout", func() {
+		c.Files.URLs = []string{server.URL + "/slow"}
+		c.Files.URLTimeout = 10 * time.Millisecond
+
+		Expect(c.readFiles(nil)).To(MatchError(ErrRemoteFile))
+	})
+})
@@ -0,0 +1,141 @@
+package alligotor
+
+import (
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const saveFileMode = 0600
+
+// Save writes the configuration in v to a file at filePath, e.g. to generate a starter config with the defaults.
+// The format is chosen by the file extension, currently .json, .yaml and .yml are supported.
+// The keys are the file field names that are used by Get, so fields with a file key in the struct tag are
+// written with that key and nested structs are written as nested objects using the Files.Separator.
+// Values are written in a format that can be read by Get again, e.g. durations as duration strings,
+// timestamps with the timeformat from the struct tag, byte slices as base64 strings and types implementing encoding.TextMarshaler as text.
+// If Files.UseNamespace is true the values are written to an object at the Namespace key.
+// The file is created with permissions 0600 since it may contain secrets.
+func (c *Collector) Save(v interface{}, filePath string) error {
+	value := reflect.ValueOf(v)
+	if err := checkStructPointer(value); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fields, err := getFieldsConfigsFromValue(value.Elem())
+	if err != nil {
+		return err
+	}
+
+	separator := separatorOrDefault(c.Files.Separator)
+	m := map[string]interface{}{}
+
+	for _, f := range fields {
+		if !f.Value.IsValid() || !f.Value.CanInterface() {
+			continue
+		}
+
+		fileValue, ok, err := toFileValue(f)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			continue
+		}
+
+		key := f.Config.DefaultFileField
+		if key == "" {
+			key = f.fileNameWith(separator, c.Files.Naming)
+		}
+
+		setNested(m, strings.Split(key, separator), fileValue)
+	}
+
+	if c.Files.UseNamespace && c.Namespace != "" {
+		m = map[string]interface{}{c.Namespace: m}
+	}
+
+	var fileBytes []byte
+
+	switch strings.ToLower(path.Ext(filePath)) {
+	case ".json":
+		fileBytes, err = json.MarshalIndent(m, "", "  ")
+	case ".yaml", ".yml":
+		fileBytes, err = yaml.Marshal(m)
+	default:
+		return ErrFileTypeNotSupported
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filePath, fileBytes, saveFileMode)
+}
+
+// toFileValue returns the value of the field in a format that can be read from a file again.
+// It returns false for structs since their fields are written separately.
+func toFileValue(f *field) (interface{}, bool, error) {
+	value := f.Value.Interface()
+
+	switch typedValue := value.(type) {
+	case time.Duration:
+		return typedValue.String(), true, nil
+	case time.Time:
+		return formatTime(typedValue, f.Config.TimeFormat), true, nil
+	case []byte:
+		encoding := f.Config.Base64Encoding
+		if encoding == nil {
+			encoding = base64.StdEncoding
+		}
+
+		return encoding.EncodeToString(typedValue), true, nil
+	case encoding.TextMarshaler:
+		text, err := typedValue.MarshalText()
+		if err != nil {
+			return nil, false, err
+		}
+
+		return string(text), true, nil
+	}
+
+	if f.Value.Kind() == reflect.Struct {
+		return nil, false, nil
+	}
+
+	return value, true, nil
+}
+
+// setNested sets the value in m at the path of keys, creating nested maps as needed.
+func setNested(m map[string]interface{}, keys []string, value interface{}) {
+	for _, key := range keys[:len(keys)-1] {
+		nested, ok := m[key].(map[string]interface{})
+		if !ok {
+			nested = map[string]interface{}{}
+			m[key] = nested
+		}
+
+		m = nested
+	}
+
+	m[keys[len(keys)-1]] = value
+}
+
+func separatorOrDefault(separator string) string {
+	if separator == "" {
+		return defaultSeparator
+	}
+
+	return separator
+}
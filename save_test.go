@@ -0,0 +1,106 @@
+package alligotor
+
+import (
+	"os"
+	"path"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Save", func() {
+	type saveConfig struct {
+		Port    int
+		Timeout time.Duration
+		Key     []byte `config:"base64=url"`
+		Tags    []string
+		API     struct {
+			Host    string `config:"file=hostname"`
+			Started time.Time
+		}
+	}
+
+	var dir string
+	var c *Collector
+	var cfg saveConfig
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "tests*")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		c = &Collector{
+			Files: FilesConfig{Locations: []string{dir}, BaseName: "config", Separator: "."},
+			Env:   EnvConfig{Disabled: true},
+			Flags: FlagsConfig{Disabled: true},
+		}
+
+		cfg = saveConfig{Port: 8080, Timeout: 5 * time.Second, Key: []byte{0xfb, 0xff}, Tags: []string{"a", "b"}}
+		cfg.API.Host = "localhost"
+		cfg.API.Started = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	})
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("returns error if v is not a pointer", func() {
+		Expect(c.Save(cfg, path.Join(dir, "config.json"))).To(Equal(ErrPointerExpected))
+	})
+	It("returns error for unsupported file types", func() {
+		Expect(c.Save(&cfg, path.Join(dir, "config.txt"))).To(Equal(ErrFileTypeNotSupported))
+	})
+	It("uses the file field names", func() {
+		Expect(c.Save(&cfg, path.Join(dir, "config.json"))).To(Succeed())
+
+		fileBytes, err := os.ReadFile(path.Join(dir, "config.json"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(fileBytes).To(MatchJSON(`{
+			"Port": 8080,
+			"Timeout": "5s",
+			"Key": "-_8=",
+			"Tags": ["a", "b"],
+			"hostname": "localhost",
+			"API": {"Started": "2020-01-02T03:04:05Z"}
+		}`))
+	})
+	for _, ext := range []string{".json", ".yaml", ".yml"} {
+		ext := ext
+
+		It("round trips with Get for "+ext, func() {
+			Expect(c.Save(&cfg, path.Join(dir, "config"+ext))).To(Succeed())
+
+			loaded := saveConfig{}
+			Expect(c.Get(&loaded)).To(Succeed())
+			Expect(loaded).To(Equal(cfg))
+		})
+	}
+	It("round trips timestamps with the time format from the struct tag", func() {
+		started := time.Date(2020, 9, 13, 12, 26, 0, 0, time.UTC)
+		timeCfg := struct {
+			Default   time.Time
+			Unix      time.Time `config:"timeformat=unix"`
+			UnixMilli time.Time `config:"timeformat=unixmilli"`
+			Layout    time.Time `config:"timeformat=02.01.2006 15:04"`
+		}{started, started, started, started}
+
+		Expect(c.Save(&timeCfg, path.Join(dir, "config.json"))).To(Succeed())
+
+		fileBytes, err := os.ReadFile(path.Join(dir, "config.json"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(fileBytes).To(MatchJSON(`{
+			"Default": "2020-09-13T12:26:00Z",
+			"Unix": "1599999960",
+			"UnixMilli": "1599999960000",
+			"Layout": "13.09.2020 12:26"
+		}`))
+
+		loaded := timeCfg
+		loaded.Default, loaded.Unix, loaded.UnixMilli, loaded.Layout = time.Time{}, time.Time{}, time.Time{}, time.Time{}
+		Expect(c.Get(&loaded)).To(Succeed())
+		Expect(loaded.Default).To(BeTemporally("==", started))
+		Expect(loaded.Unix).To(BeTemporally("==", started))
+		Expect(loaded.UnixMilli).To(BeTemporally("==", started))
+		Expect(loaded.Layout).To(BeTemporally("==", started))
+	})
+})
@@ -0,0 +1,76 @@
+package alligotor
+
+// SourceCustom is the SourceKind of the values read from the Collector's Sources.
+const SourceCustom SourceKind = "source"
+
+// Source is a custom configuration source, e.g. a key value store like Consul or etcd.
+// Values returns the keys and values of the source. The keys are matched just like the keys in config files,
+// so nested fields are set with keys joined by the Files.Separator (e.g. db.host) and the values are converted
+// just like environment variables.
+type Source interface {
+	Values() (map[string]string, error)
+}
+
+// readSources sets the fields from the values of the Collector's Sources, later Sources take precedence.
+func (c *Collector) readSources(fields []*field) error {
+	for _, source := range c.Sources {
+		values, err := source.Values()
+		if err != nil {
+			return err
+		}
+
+		m := newCiMap(withSeparator(c.Files.Separator))
+		for key, value := range values {
+			m.m[key] = value
+		}
+
+		if err := c.readSourceMap(fields, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readSourceMap sets the fields from the string values in m.
+func (c *Collector) readSourceMap(fields []*field, m *ciMap) error {
+	for _, f := range fields {
+		for _, key := range []string{c.explicitFileField(f, m), c.fileFieldName(f)} {
+			if key == "" {
+				continue
+			}
+
+			value, ok := m.Get(key)
+			if !ok {
+				continue
+			}
+
+			valueString, ok := value.(string)
+			if !ok {
+				continue
+			}
+
+			if err := c.setFromString(f, valueString); err != nil {
+				return err
+			}
+
+			c.record(f, SourceCustom, key, valueString)
+		}
+	}
+
+	return nil
+}
+
+// readSourcesAfter reads the Sources if they are configured to be read after the given kind of source.
+func (c *Collector) readSourcesAfter(kind SourceKind, fields []*field) error {
+	after := c.SourcesAfter
+	if after == "" {
+		after = SourceFile
+	}
+
+	if after != kind {
+		return nil
+	}
+
+	return c.readSources(fields)
+}
@@ -0,0 +1,64 @@
+package alligotor
+
+import (
+	"errors"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type testSource map[string]string
+
+func (s testSource) Values() (map[string]string, error) {
+	return s, nil
+}
+
+type failingSource struct{ err error }
+
+func (s failingSource) Values() (map[string]string, error) {
+	return nil, s.err
+}
+
+var _ = Describe("Sources", func() {
+	var c *Collector
+	var cfg struct {
+		Port int
+		DB   struct{ Host string }
+	}
+
+	BeforeEach(func() {
+		cfg.Port = 0
+		cfg.DB.Host = ""
+		c = &Collector{
+			Files: FilesConfig{Disabled: true, Separator: "."},
+			Env:   EnvConfig{Prefix: "SOURCETEST", Separator: "_"},
+			Flags: FlagsConfig{Disabled: true},
+		}
+		Expect(os.Setenv("SOURCETEST_PORT", "1")).To(Succeed())
+	})
+	AfterEach(func() {
+		Expect(os.Unsetenv("SOURCETEST_PORT")).To(Succeed())
+	})
+
+	It("reads the sources before env by default", func() {
+		c.Sources = []Source{testSource{"port": "2", "db.host": "a"}, testSource{"db.host": "b"}}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Port).To(Equal(1))
+		Expect(cfg.DB.Host).To(Equal("b"))
+	})
+	It("reads the sources after the configured kind of source", func() {
+		c.Sources = []Source{testSource{"port": "2"}}
+		c.SourcesAfter = SourceEnv
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Port).To(Equal(2))
+	})
+	It("returns errors of the sources", func() {
+		errSource := errors.New("source error")
+		c.Sources = []Source{failingSource{err: errSource}}
+
+		Expect(c.Get(&cfg)).To(MatchError(errSource))
+	})
+})
@@ -0,0 +1,70 @@
+package alligotor
+
+import (
+	stdflag "flag"
+	"io"
+	"reflect"
+)
+
+// readStdFlags reads the flags just like readPFlags, but parses them with the flag package of the standard library.
+// The same names are registered for the fields, but shorthands are not supported.
+func (c *Collector) readStdFlags(fields []*field, args []string) error {
+	flagSet := stdflag.NewFlagSet("config", stdflag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
+
+	fieldToFlagNames := c.mapFlags(fields, func(f *field, name, _, usage string) {
+		flagSet.Var(&stdFlagValue{isBool: f.Value.Kind() == reflect.Bool}, name, usage)
+	})
+
+	if c.Files.PathFlag != "" {
+		flagSet.String(c.Files.PathFlag, "", pathFlagUsage)
+	}
+
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	for i, f := range fields {
+		for _, name := range fieldToFlagNames[i] {
+			value := flagSet.Lookup(name).Value.(*stdFlagValue)
+
+			// differentiate a flag that is not set from a flag that is set to ""
+			if len(value.values) == 0 {
+				continue
+			}
+
+			if err := c.setFromFlagValues(f, value.values); err != nil {
+				return err
+			}
+
+			c.record(f, SourceFlag, name, value.String())
+		}
+	}
+
+	return nil
+}
+
+// stdFlagValue implements flag.Value and collects all values of a flag, so flags for slices can be repeated.
+type stdFlagValue struct {
+	values []string
+	isBool bool
+}
+
+func (v *stdFlagValue) String() string {
+	if v == nil || len(v.values) == 0 {
+		return ""
+	}
+
+	return v.values[len(v.values)-1]
+}
+
+func (v *stdFlagValue) Set(value string) error {
+	v.values = append(v.values, value)
+
+	return nil
+}
+
+// IsBoolFlag allows flags for bool fields to be set without a value.
+func (v *stdFlagValue) IsBoolFlag() bool {
+	return v.isBool
+}
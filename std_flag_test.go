@@ -0,0 +1,58 @@
+package alligotor
+
+import (
+	"reflect"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("readStdFlags", func() {
+	var c *Collector
+
+	type stdFlagConfig struct {
+		Port    int `config:"flag=p"`
+		Verbose bool
+		Tags    []string
+		API     struct{ Host string }
+	}
+
+	var cfg stdFlagConfig
+	var fields []*field
+
+	BeforeEach(func() {
+		c = &Collector{Flags: FlagsConfig{Separator: "-", UseStdFlag: true}}
+
+		cfg = stdFlagConfig{}
+
+		var err error
+		fields, err = getFieldsConfigsFromValue(reflect.ValueOf(&cfg).Elem())
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("sets fields from single and double dash flags", func() {
+		Expect(c.readStdFlags(fields, []string{"-port", "1", "--api-host=localhost", "-verbose"})).To(Succeed())
+		Expect(cfg.Port).To(Equal(1))
+		Expect(cfg.API.Host).To(Equal("localhost"))
+		Expect(cfg.Verbose).To(BeTrue())
+	})
+	It("appends repeated flags to slices", func() {
+		Expect(c.readStdFlags(fields, []string{"-tags", "a", "-tags", "b,c"})).To(Succeed())
+		Expect(cfg.Tags).To(Equal([]string{"a", "b", "c"}))
+	})
+	It("ignores args after the flag terminator", func() {
+		Expect(c.readStdFlags(fields, []string{"-port", "8080", "--", "-port", "9090"})).To(Succeed())
+		Expect(cfg.Port).To(Equal(8080))
+	})
+	It("doesn't support shorthands", func() {
+		Expect(c.readStdFlags(fields, []string{"-p", "1"})).NotTo(Succeed())
+	})
+	It("is used by Get if configured", func() {
+		c.Files.Disabled = true
+		c.Env.Disabled = true
+		c.Flags.Args = []string{"-port", "2"}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Port).To(Equal(2))
+	})
+})
@@ -0,0 +1,35 @@
+package alligotor
+
+import (
+	"io"
+	"os"
+)
+
+// stdinPath is used as path of the config file that is read from stdin.
+const stdinPath = "-"
+
+// stdinAvailable returns true if stdin is not a terminal, so reading it doesn't wait for user input.
+func stdinAvailable() bool {
+	fileInfo, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+
+	return fileInfo.Mode()&os.ModeCharDevice == 0
+}
+
+// readStdin returns the content of stdin. It's only read once and cached, so it can be used by multiple calls to Get.
+func (c *Collector) readStdin() ([]byte, error) {
+	if c.stdin != nil {
+		return c.stdin, nil
+	}
+
+	stdinBytes, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, err
+	}
+
+	c.stdin = stdinBytes
+
+	return c.stdin, nil
+}
@@ -0,0 +1,75 @@
+package alligotor
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stdin", func() {
+	var c *Collector
+	var originalStdin *os.File
+	var cfg struct {
+		Port int
+		Name string
+	}
+
+	setStdin := func(content string) {
+		r, w, err := os.Pipe()
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = w.WriteString(content)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(w.Close()).To(Succeed())
+
+		os.Stdin = r
+	}
+
+	BeforeEach(func() {
+		originalStdin = os.Stdin
+		cfg.Port, cfg.Name = 0, ""
+		c = &Collector{
+			Files: FilesConfig{Separator: ".", Stdin: true},
+			Env:   EnvConfig{Disabled: true},
+			Flags: FlagsConfig{Disabled: true},
+		}
+	})
+	AfterEach(func() {
+		os.Stdin = originalStdin
+	})
+
+	It("reads the config from stdin once", func() {
+		setStdin("port: 1\nname: stdin\n")
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Port).To(Equal(1))
+		Expect(c.LoadedFiles()).To(Equal([]string{"-"}))
+
+		cfg.Port = 0
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Port).To(Equal(1))
+	})
+	It("detects json", func() {
+		setStdin(`{"name":"json"}`)
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Name).To(Equal("json"))
+	})
+	It("doesn't read stdin if not enabled", func() {
+		setStdin("port: 1\n")
+		c.Files.Stdin = false
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Port).To(Equal(0))
+	})
+	It("reads stdin for the path - from the path env var", func() {
+		setStdin("port: 2\n")
+		c.Files.Stdin = false
+		c.Files.PathEnvVar = "STDINTEST_CONFIG"
+		Expect(os.Setenv("STDINTEST_CONFIG", "-")).To(Succeed())
+		defer os.Unsetenv("STDINTEST_CONFIG")
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Port).To(Equal(2))
+	})
+})
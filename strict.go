@@ -0,0 +1,82 @@
+package alligotor
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrUnknownKey is returned in strict mode if a config file contains keys that don't map to any field.
+var ErrUnknownKey = errors.New("unknown key")
+
+// checkUnknownKeys returns an error listing all keys in m that are not read by any of the fields.
+// A key is read by a field if it matches the field's file key, keys nested in the value of a map field or a field
+// that implements an unmarshaler (e.g. json.Unmarshaler) are read by that field as well.
+func (c *Collector) checkUnknownKeys(fields []*field, m *ciMap, filePath string) error {
+	var unknownKeys []string
+
+	for _, key := range m.keyPaths() {
+		if !c.isKnownKey(fields, m, key) {
+			unknownKeys = append(unknownKeys, key)
+		}
+	}
+
+	if len(unknownKeys) == 0 {
+		return nil
+	}
+
+	sort.Strings(unknownKeys)
+
+	return fmt.Errorf("%w in %s: %s", ErrUnknownKey, filePath, strings.Join(unknownKeys, ", "))
+}
+
+func (c *Collector) isKnownKey(fields []*field, m *ciMap, key string) bool {
+	normalizedKey := m.normalizeKey(key)
+
+	for _, f := range fields {
+		fieldNames := append([]string{f.Config.DefaultFileField, c.fileFieldName(f)}, f.Config.FileAliases...)
+		for _, fieldName := range fieldNames {
+			if fieldName == "" {
+				continue
+			}
+
+			normalizedName := m.normalizeKey(fieldName)
+			if normalizedKey == normalizedName {
+				return true
+			}
+
+			if acceptsNestedKeys(f) && strings.HasPrefix(normalizedKey, normalizedName+m.separator) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// acceptsNestedKeys returns true for fields that are decoded as a whole, including the nested keys of their value.
+func acceptsNestedKeys(f *field) bool {
+	return f.Value.Kind() == reflect.Map || f.Value.IsValid() && isUnmarshaler(f.Value.Type())
+}
+
+// keyPaths returns the paths of all leaf values in the map with the keys of nested maps joined by the separator.
+func (c ciMap) keyPaths() []string {
+	var paths []string
+
+	for key, val := range c.m {
+		nested, ok := val.(map[string]interface{})
+		if !ok || len(nested) == 0 {
+			paths = append(paths, key)
+
+			continue
+		}
+
+		for _, nestedPath := range (ciMap{m: nested, separator: c.separator}).keyPaths() {
+			paths = append(paths, key+c.separator+nestedPath)
+		}
+	}
+
+	return paths
+}
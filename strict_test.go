@@ -0,0 +1,73 @@
+package alligotor
+
+import (
+	"errors"
+	"os"
+	"path"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Strict", func() {
+	type strictConfig struct {
+		Port     int
+		Labels   map[string]string
+		Database struct {
+			Host string `config:"file=db_host"`
+			Name string
+		}
+	}
+
+	var dir string
+	var c *Collector
+	var cfg strictConfig
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "tests*")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		c = &Collector{
+			Files: FilesConfig{Locations: []string{dir}, BaseName: "config", Separator: ".", Strict: true},
+			Env:   EnvConfig{Disabled: true},
+			Flags: FlagsConfig{Disabled: true},
+		}
+		cfg = strictConfig{}
+	})
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("accepts keys that map to fields", func() {
+		yamlBytes := []byte("port: 1\nlabels:\n  a: b\ndb_host: localhost\ndatabase:\n  name: test\n")
+		Expect(os.WriteFile(path.Join(dir, "config.yaml"), yamlBytes, 0600)).To(Succeed())
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Labels).To(Equal(map[string]string{"a": "b"}))
+		Expect(cfg.Database.Host).To(Equal("localhost"))
+	})
+	It("returns error listing unknown keys", func() {
+		yamlBytes := []byte("port: 1\ndatabse:\n  name: test\ndatabase:\n  nmae: test\n")
+		Expect(os.WriteFile(path.Join(dir, "config.yaml"), yamlBytes, 0600)).To(Succeed())
+
+		err := c.Get(&cfg)
+		Expect(errors.Is(err, ErrUnknownKey)).To(BeTrue())
+		Expect(err.Error()).To(HaveSuffix(": database.nmae, databse.name"))
+	})
+	It("accepts nested keys of fields that implement an unmarshaler", func() {
+		yamlBytes := []byte("custom:\n  a: x\n  b: y\n")
+		Expect(os.WriteFile(path.Join(dir, "config.yaml"), yamlBytes, 0600)).To(Succeed())
+
+		customCfg := struct{ Custom testJSONType }{}
+		Expect(c.Get(&customCfg)).To(Succeed())
+		Expect(customCfg.Custom.Names).To(Equal([]string{"a", "b"}))
+	})
+	It("ignores unknown keys if not enabled", func() {
+		c.Files.Strict = false
+		Expect(os.WriteFile(path.Join(dir, "config.yaml"), []byte("port: 1\ndatabse: {}\n"), 0600)).To(Succeed())
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Port).To(Equal(1))
+	})
+})
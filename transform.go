@@ -0,0 +1,99 @@
+package alligotor
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrUnknownTransform is returned if a transform in a struct tag is neither built-in nor registered.
+var ErrUnknownTransform = errors.New("unknown transform")
+
+// builtinTransforms returns the transforms that can be used without registering them.
+func builtinTransforms() map[string]func(string) string {
+	return map[string]func(string) string{
+		"lower": strings.ToLower,
+		"upper": strings.ToUpper,
+		"trim":  strings.TrimSpace,
+	}
+}
+
+// RegisterTransform registers a transform that can be used with the transform key in the struct tag,
+// e.g. `config:"transform=name"`. Registering a transform with the name of a built-in transform replaces it.
+func (c *Collector) RegisterTransform(name string, fn func(string) string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.transforms == nil {
+		c.transforms = map[string]func(string) string{}
+	}
+
+	c.transforms[name] = fn
+}
+
+// applyTransforms applies the transforms defined in the struct tags to the string fields and string slices.
+// Nil pointers are skipped.
+func (c *Collector) applyTransforms(fields []*field) error {
+	for _, f := range fields {
+		if len(f.Config.Transforms) == 0 {
+			continue
+		}
+
+		transform, err := c.transform(f.Config.Transforms)
+		if err != nil {
+			return fmt.Errorf("%w of %s", err, f.FullName("."))
+		}
+
+		if !isStringOrStringSlice(f.valueType()) {
+			return fmt.Errorf("%w: transforms are only supported for strings, not for %s", ErrUnsupportedType, f.FullName("."))
+		}
+
+		if !f.Value.IsValid() {
+			continue
+		}
+
+		if f.Value.Kind() == reflect.String {
+			f.Value.SetString(transform(f.Value.String()))
+
+			continue
+		}
+
+		// copy the slice to not modify the backing array of the original value
+		slice := reflect.MakeSlice(f.Value.Type(), f.Value.Len(), f.Value.Len())
+		for i := 0; i < f.Value.Len(); i++ {
+			slice.Index(i).SetString(transform(f.Value.Index(i).String()))
+		}
+
+		f.Value.Set(slice)
+	}
+
+	return nil
+}
+
+// transform returns a function that applies all transforms with the given names in order.
+func (c *Collector) transform(names []string) (func(string) string, error) {
+	builtins := builtinTransforms()
+	transforms := make([]func(string) string, 0, len(names))
+
+	for _, name := range names {
+		transform, ok := c.transforms[name]
+		if !ok {
+			transform, ok = builtins[name]
+		}
+
+		if !ok {
+			return nil, fmt.Errorf("%w %q", ErrUnknownTransform, name)
+		}
+
+		transforms = append(transforms, transform)
+	}
+
+	return func(value string) string {
+		for _, transform := range transforms {
+			value = transform(value)
+		}
+
+		return value
+	}, nil
+}
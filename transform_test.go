@@ -0,0 +1,88 @@
+package alligotor
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("transforms", func() {
+	var c *Collector
+
+	BeforeEach(func() {
+		c = &Collector{
+			Files: FilesConfig{Disabled: true},
+			Env:   EnvConfig{Disabled: true},
+			Flags: FlagsConfig{Separator: "-"},
+		}
+	})
+
+	It("applies built-in transforms in order", func() {
+		cfg := struct {
+			Email string   `config:"transform=trim lower"`
+			Codes []string `config:"transform=upper"`
+		}{}
+		c.Flags.Args = []string{"--email", "  Me@Example.COM ", "--codes", "a,b"}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Email).To(Equal("me@example.com"))
+		Expect(cfg.Codes).To(Equal([]string{"A", "B"}))
+	})
+	It("applies transforms to defaults", func() {
+		cfg := struct {
+			Name string `config:"transform=upper"`
+		}{Name: "default"}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Name).To(Equal("DEFAULT"))
+	})
+	It("applies registered transforms", func() {
+		c.RegisterTransform("nodash", func(s string) string { return strings.ReplaceAll(s, "-", "") })
+		cfg := struct {
+			ID string `config:"transform=nodash upper"`
+		}{}
+		c.Flags.Args = []string{"--id", "ab-cd"}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.ID).To(Equal("ABCD"))
+	})
+	It("returns error for unknown transforms", func() {
+		cfg := struct {
+			Name string `config:"transform=unknown"`
+		}{}
+
+		Expect(errors.Is(c.Get(&cfg), ErrUnknownTransform)).To(BeTrue())
+	})
+	It("returns error for fields that are not strings", func() {
+		cfg := struct {
+			Port int `config:"transform=trim"`
+		}{}
+
+		Expect(errors.Is(c.Get(&cfg), ErrUnsupportedType)).To(BeTrue())
+
+		ptrCfg := struct {
+			Port *int `config:"transform=trim"`
+		}{}
+
+		Expect(errors.Is(c.Get(&ptrCfg), ErrUnsupportedType)).To(BeTrue())
+	})
+	It("skips nil pointers and transforms the values of other pointers", func() {
+		cfg := struct {
+			Name  *string `config:"transform=lower"`
+			Port  *int    `config:"min=1,max=10"`
+			Level *string `config:"oneof=a b"`
+		}{}
+
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(cfg.Name).To(BeNil())
+		Expect(cfg.Port).To(BeNil())
+		Expect(cfg.Level).To(BeNil())
+
+		name := "NAME"
+		cfg.Name = &name
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(name).To(Equal("name"))
+	})
+})
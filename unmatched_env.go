@@ -0,0 +1,42 @@
+package alligotor
+
+import (
+	"sort"
+	"strings"
+)
+
+// UnmatchedEnv returns the environment variables with the Env.Prefix (or Namespace) that were set during the last
+// call to Get but didn't match any field, sorted by name. These are most likely typos, e.g. MYAPP_PROT instead of
+// MYAPP_PORT. It's always empty if there is no prefix or reading environment variables is disabled.
+func (c *Collector) UnmatchedEnv() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]string(nil), c.unmatchedEnv...)
+}
+
+// findUnmatchedEnv returns the sorted names of the variables with the prefix that were not consumed by readEnv.
+func (c *Collector) findUnmatchedEnv(vars map[string]string) []string {
+	if c.envPrefix() == "" {
+		return nil
+	}
+
+	prefix := strings.ToUpper(c.envPrefix() + c.Env.Separator)
+
+	var unmatched []string
+
+	for name := range vars {
+		// the variables for the config file and profile are used as well, even if they don't match a field
+		if name == c.Files.PathEnvVar || name == c.Files.ProfileEnvVar {
+			continue
+		}
+
+		if strings.HasPrefix(strings.ToUpper(name), prefix) && !c.consumedEnv[name] {
+			unmatched = append(unmatched, name)
+		}
+	}
+
+	sort.Strings(unmatched)
+
+	return unmatched
+}
@@ -0,0 +1,56 @@
+package alligotor
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UnmatchedEnv", func() {
+	var c *Collector
+	var cfg struct {
+		Port     int
+		Password string
+		Tags     []string
+		Debug    bool `config:"env=UNMATCHEDTEST_VERBOSE"`
+	}
+	vars := map[string]string{
+		"UNMATCHEDTEST_PORT":          "1",
+		"UNMATCHEDTEST_PROT":          "2",
+		"UNMATCHEDTEST_PASSWORD_FILE": "/dev/null",
+		"UNMATCHEDTEST_TAGS_0":        "a",
+		"UNMATCHEDTEST_VERBOSE":       "true",
+		"UNMATCHEDTEST_CONFIG":        "",
+		"unmatchedtest_lower":         "3",
+		"OTHER_UNMATCHEDTEST_PORT":    "4",
+	}
+
+	BeforeEach(func() {
+		for name, value := range vars {
+			Expect(os.Setenv(name, value)).To(Succeed())
+		}
+
+		c = &Collector{
+			Files: FilesConfig{Disabled: true, PathEnvVar: "UNMATCHEDTEST_CONFIG"},
+			Env:   EnvConfig{Prefix: "UNMATCHEDTEST", Separator: "_", FileSuffix: "_FILE"},
+			Flags: FlagsConfig{Disabled: true},
+		}
+	})
+	AfterEach(func() {
+		for name := range vars {
+			Expect(os.Unsetenv(name)).To(Succeed())
+		}
+	})
+
+	It("returns the prefixed env vars that don't match any field", func() {
+		Expect(c.UnmatchedEnv()).To(BeEmpty())
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(c.UnmatchedEnv()).To(Equal([]string{"UNMATCHEDTEST_PROT", "unmatchedtest_lower"}))
+	})
+	It("is empty without prefix", func() {
+		c.Env.Prefix = ""
+		Expect(c.Get(&cfg)).To(Succeed())
+		Expect(c.UnmatchedEnv()).To(BeEmpty())
+	})
+})
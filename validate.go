@@ -0,0 +1,14 @@
+package alligotor
+
+// validate checks the field values against the constraints defined in the struct tags.
+func validate(fields []*field, groups map[string]GroupRule) error {
+	if err := checkRanges(fields); err != nil {
+		return err
+	}
+
+	if err := checkOneOf(fields); err != nil {
+		return err
+	}
+
+	return checkGroups(fields, groups)
+}
@@ -0,0 +1,232 @@
+package alligotor
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// ErrNothingToWatch is returned by Collector.Watch if none of the configured file locations can be watched.
+var ErrNothingToWatch = errors.New("no file location to watch")
+
+const watchDebounce = 100 * time.Millisecond
+
+// Watch loads the configuration into v just like Get and afterwards watches the configured file locations
+// (including the ones matched by glob patterns) for changes to config files. If the config file is set by the
+// PathFlag or PathEnvVar only that file is watched, ErrNothingToWatch is returned if it's a URL or stdin. On every change the configuration is loaded again from all enabled sources,
+// so env variables and flags still take precedence over the files. onChange is called after every reload
+// with the error that occurred or nil if v was updated successfully.
+//
+// The values v held before calling Watch are used as the defaults for every reload.
+// Rapid successive changes (e.g. editors writing a file in multiple steps) are debounced into a single reload.
+// Each reload is collected into a separate copy first and only assigned to v if it succeeded, so v is never
+// left in a partially loaded state. Reloads and the calls to onChange happen sequentially in a single goroutine.
+// If locker is not nil it's held while the reloaded configuration is assigned to v, so v can be read concurrently
+// while holding the same lock, e.g. the read lock of a sync.RWMutex that is passed as locker.
+// If it's nil reading v concurrently to a reload is a data race and only safe in onChange.
+//
+// The returned stop function stops watching, it is safe to be called multiple times.
+func (c *Collector) Watch(v interface{}, locker sync.Locker, onChange func(error)) (stop func(), err error) {
+	value := reflect.ValueOf(v)
+	if err := checkPointer(value); err != nil {
+		return nil, err
+	}
+
+	defaults := deepCopy(value.Elem())
+
+	if err := c.Get(v); err != nil {
+		return nil, err
+	}
+
+	watcher, isConfigFile, err := c.newFileWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	done := make(chan struct{})
+
+	go c.watch(watcher, isConfigFile, done, func() {
+		onChange(c.reload(value, defaults, locker))
+	}, onChange)
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			close(done)
+			_ = watcher.Close()
+		})
+	}, nil
+}
+
+// newFileWatcher returns a watcher for the directories that contain config files
+// and a function that returns true for the paths of the config files in them.
+func (c *Collector) newFileWatcher() (*fsnotify.Watcher, func(string) bool, error) {
+	if c.Files.Disabled {
+		return nil, nil, ErrNothingToWatch
+	}
+
+	dirs, isConfigFile, err := c.watchTargets()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	watching := false
+
+	for _, dir := range dirs {
+		// locations that don't exist are skipped just like in readFiles
+		if err := watcher.Add(dir); err != nil {
+			continue
+		}
+
+		watching = true
+	}
+
+	if !watching {
+		_ = watcher.Close()
+
+		return nil, nil, ErrNothingToWatch
+	}
+
+	return watcher, isConfigFile, nil
+}
+
+// watchTargets returns the directories to watch and a function that returns true for the config files in them.
+// If the config file is set by the PathFlag or PathEnvVar only that file is watched,
+// which isn't possible for URLs and stdin.
+func (c *Collector) watchTargets() ([]string, func(string) bool, error) {
+	filePath, ok := c.explicitFilePath()
+	if !ok {
+		return c.Files.watchDirs(), c.Files.isConfigFile, nil
+	}
+
+	if filePath == stdinPath || isURL(filePath) {
+		return nil, nil, ErrNothingToWatch
+	}
+
+	filePath = filepath.Clean(filePath)
+
+	return []string{filepath.Dir(filePath)}, func(name string) bool {
+		return filepath.Clean(name) == filePath
+	}, nil
+}
+
+func (c *Collector) watch(
+	watcher *fsnotify.Watcher, isConfigFile func(string) bool, done <-chan struct{}, reload func(), onError func(error),
+) {
+	var debounce <-chan time.Time
+
+	for {
+		select {
+		case <-done:
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+
+			if !isConfigFile(event.Name) {
+				continue
+			}
+
+			debounce = time.After(watchDebounce)
+		case <-debounce:
+			debounce = nil
+
+			reload()
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+
+			onError(err)
+		}
+	}
+}
+
+// watchDirs returns the directories that can contain config files: the literal Locations as well as the
+// directories matched by glob patterns and the directories of the files matched by them.
+// The directory part of a glob pattern is added too if it's literal, so files matching the pattern
+// that are created later are noticed as well.
+func (config FilesConfig) watchDirs() []string {
+	var dirs []string
+
+	for _, location := range config.Locations {
+		if !strings.ContainsAny(location, globMetaChars) {
+			dirs = append(dirs, location)
+
+			continue
+		}
+
+		if dir := filepath.Dir(location); !strings.ContainsAny(dir, globMetaChars) {
+			dirs = append(dirs, dir)
+		}
+
+		// malformed patterns are already reported by the initial Get
+		matches, _ := filepath.Glob(location)
+		for _, match := range matches {
+			if fileInfo, err := os.Stat(match); err == nil && fileInfo.IsDir() {
+				dirs = append(dirs, match)
+			} else {
+				dirs = append(dirs, filepath.Dir(match))
+			}
+		}
+	}
+
+	return distinctNames(dirs)
+}
+
+// isConfigFile returns true if the file is read as config file,
+// which is the case if it matches one of the base names or one of the glob patterns in the Locations.
+func (config FilesConfig) isConfigFile(filePath string) bool {
+	if config.matchesBaseName(filepath.Base(filePath)) {
+		return true
+	}
+
+	for _, location := range config.Locations {
+		if !strings.ContainsAny(location, globMetaChars) {
+			continue
+		}
+
+		if ok, _ := filepath.Match(location, filePath); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reload loads the configuration into a copy of defaults and only assigns it to target if that succeeded.
+// The Collector's lock is held during the reload to serialize it with other calls to Get,
+// the locker is only held during the assignment if it's not nil.
+func (c *Collector) reload(target, defaults reflect.Value, locker sync.Locker) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fresh := reflect.New(defaults.Type())
+	fresh.Elem().Set(deepCopy(defaults))
+
+	if err := c.get(fresh.Interface(), true); err != nil {
+		return err
+	}
+
+	if locker != nil {
+		locker.Lock()
+		defer locker.Unlock()
+	}
+
+	target.Elem().Set(fresh.Elem())
+
+	return nil
+}
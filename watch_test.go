@@ -0,0 +1,147 @@
+package alligotor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Watch", func() {
+	var dir string
+	var c *Collector
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "tests*")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		c = &Collector{
+			Files: FilesConfig{
+				Locations: []string{dir},
+				BaseName:  "config",
+				Separator: ".",
+			},
+			Env:   EnvConfig{Disabled: true},
+			Flags: FlagsConfig{Disabled: true},
+		}
+	})
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("returns error if v is not a pointer", func() {
+		_, err := c.Watch(struct{}{}, nil, func(error) {})
+		Expect(err).To(Equal(ErrPointerExpected))
+	})
+	It("returns error if there is no location to watch", func() {
+		c.Files.Locations = []string{path.Join(dir, "not-existing")}
+		_, err := c.Watch(&struct{}{}, nil, func(error) {})
+		Expect(err).To(Equal(ErrNothingToWatch))
+	})
+	It("reloads on file changes and keeps defaults", func() {
+		Expect(os.WriteFile(path.Join(dir, "config.json"), []byte(`{"port": 1}`), 0600)).To(Succeed())
+
+		cfg := struct {
+			Port int
+			Host string
+		}{Host: "default"}
+
+		var mu sync.RWMutex
+
+		ports := make(chan int, 10)
+		stop, err := c.Watch(&cfg, &mu, func(err error) {
+			Expect(err).ShouldNot(HaveOccurred())
+			ports <- cfg.Port
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		defer stop()
+
+		Expect(cfg.Port).To(Equal(1))
+
+		Expect(os.WriteFile(path.Join(dir, "config.json"), []byte(`{"port": 2}`), 0600)).To(Succeed())
+		Eventually(func() int {
+			mu.RLock()
+			defer mu.RUnlock()
+
+			return cfg.Port
+		}).Should(Equal(2))
+		Eventually(ports).Should(Receive(Equal(2)))
+		Expect(cfg.Host).To(Equal("default"))
+
+		stop()
+		stop()
+	})
+	It("watches the files matched by glob patterns", func() {
+		Expect(os.Mkdir(path.Join(dir, "conf.d"), 0700)).To(Succeed())
+		Expect(os.WriteFile(path.Join(dir, "conf.d", "app.json"), []byte(`{"port": 1}`), 0600)).To(Succeed())
+		c.Files.Locations = []string{path.Join(dir, "*.d", "*.json")}
+
+		cfg := struct{ Port int }{}
+
+		changes := make(chan error, 10)
+		stop, err := c.Watch(&cfg, nil, func(err error) { changes <- err })
+		Expect(err).ShouldNot(HaveOccurred())
+		defer stop()
+
+		Expect(cfg.Port).To(Equal(1))
+
+		Expect(os.WriteFile(path.Join(dir, "conf.d", "app.yaml"), []byte(`port: 3`), 0600)).To(Succeed())
+		Consistently(changes, 3*watchDebounce).ShouldNot(Receive())
+
+		Expect(os.WriteFile(path.Join(dir, "conf.d", "app.json"), []byte(`{"port": 2}`), 0600)).To(Succeed())
+		Eventually(changes).Should(Receive(BeNil()))
+		Expect(cfg.Port).To(Equal(2))
+	})
+	It("watches the file from the path env var", func() {
+		filePath := path.Join(dir, "app.yaml")
+		Expect(os.WriteFile(filePath, []byte(`port: 1`), 0600)).To(Succeed())
+		Expect(os.Setenv("WATCHTEST_CONFIG", filePath)).To(Succeed())
+		defer os.Unsetenv("WATCHTEST_CONFIG")
+		c.Files.PathEnvVar = "WATCHTEST_CONFIG"
+
+		cfg := struct{ Port int }{}
+
+		changes := make(chan error, 10)
+		stop, err := c.Watch(&cfg, nil, func(err error) { changes <- err })
+		Expect(err).ShouldNot(HaveOccurred())
+		defer stop()
+
+		Expect(cfg.Port).To(Equal(1))
+
+		Expect(os.WriteFile(path.Join(dir, "config.yaml"), []byte(`port: 3`), 0600)).To(Succeed())
+		Consistently(changes, 3*watchDebounce).ShouldNot(Receive())
+
+		Expect(os.WriteFile(filePath, []byte(`port: 2`), 0600)).To(Succeed())
+		Eventually(changes).Should(Receive(BeNil()))
+		Expect(cfg.Port).To(Equal(2))
+	})
+	It("returns error if the path env var is a URL", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`port: 1`))
+		}))
+		defer server.Close()
+
+		Expect(os.Setenv("WATCHTEST_CONFIG", server.URL+"/config.yaml")).To(Succeed())
+		defer os.Unsetenv("WATCHTEST_CONFIG")
+		c.Files.PathEnvVar = "WATCHTEST_CONFIG"
+
+		_, err := c.Watch(&struct{}{}, nil, func(error) {})
+		Expect(err).To(Equal(ErrNothingToWatch))
+	})
+	It("ignores changes to other files", func() {
+		cfg := struct{ Port int }{}
+
+		changes := make(chan error, 10)
+		stop, err := c.Watch(&cfg, nil, func(err error) { changes <- err })
+		Expect(err).ShouldNot(HaveOccurred())
+		defer stop()
+
+		Expect(os.WriteFile(path.Join(dir, "other.json"), []byte(`{"port": 2}`), 0600)).To(Succeed())
+		Consistently(changes, 3*watchDebounce).ShouldNot(Receive())
+	})
+})
@@ -0,0 +1,43 @@
+package alligotor
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// XDGLocations returns the config file locations for appName according to the XDG base directory specification
+// and can be used as FilesConfig.Locations.
+// The locations are returned in ascending order of precedence to work with the default LastWins file order:
+// first the system directories from $XDG_CONFIG_DIRS (defaults to /etc/xdg), with the most important one last,
+// followed by the user directory $XDG_CONFIG_HOME (defaults to $HOME/.config).
+// Relative paths in the environment variables are ignored as required by the specification.
+func XDGLocations(appName string) []string {
+	var locations []string
+
+	configDirs := filepath.SplitList(os.Getenv("XDG_CONFIG_DIRS"))
+	if len(configDirs) == 0 {
+		configDirs = []string{"/etc/xdg"}
+	}
+
+	// XDG_CONFIG_DIRS is ordered by importance, so it is reversed to have the most important one last
+	for i := len(configDirs) - 1; i >= 0; i-- {
+		if filepath.IsAbs(configDirs[i]) {
+			locations = append(locations, filepath.Join(configDirs[i], appName))
+		}
+	}
+
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if !filepath.IsAbs(configHome) {
+		configHome = ""
+
+		if home, err := os.UserHomeDir(); err == nil {
+			configHome = filepath.Join(home, ".config")
+		}
+	}
+
+	if configHome != "" {
+		locations = append(locations, filepath.Join(configHome, appName))
+	}
+
+	return locations
+}
@@ -0,0 +1,42 @@
+package alligotor
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("XDGLocations", func() {
+	var env map[string]string
+
+	BeforeEach(func() {
+		env = map[string]string{}
+		for _, key := range []string{"XDG_CONFIG_DIRS", "XDG_CONFIG_HOME", "HOME"} {
+			env[key] = os.Getenv(key)
+		}
+	})
+	AfterEach(func() {
+		for k, v := range env {
+			Expect(os.Setenv(k, v)).To(Succeed())
+		}
+	})
+
+	It("uses the defaults if nothing is set", func() {
+		Expect(os.Setenv("XDG_CONFIG_DIRS", "")).To(Succeed())
+		Expect(os.Setenv("XDG_CONFIG_HOME", "")).To(Succeed())
+		Expect(os.Setenv("HOME", "/home/gopher")).To(Succeed())
+
+		Expect(XDGLocations("myapp")).To(Equal([]string{"/etc/xdg/myapp", "/home/gopher/.config/myapp"}))
+	})
+	It("uses the environment variables in ascending precedence, ignores relative paths", func() {
+		Expect(os.Setenv("XDG_CONFIG_DIRS", "/important:relative:/less-important")).To(Succeed())
+		Expect(os.Setenv("XDG_CONFIG_HOME", "/config")).To(Succeed())
+
+		Expect(XDGLocations("myapp")).To(Equal([]string{
+			"/less-important/myapp",
+			"/important/myapp",
+			"/config/myapp",
+		}))
+	})
+})